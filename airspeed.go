@@ -0,0 +1,12 @@
+package klvparser
+
+// AirspeedDelta returns the Platform True Airspeed (tag 8) minus the Platform
+// Indicated Airspeed (tag 9), in m/s. The difference grows with altitude and
+// temperature, as the air thins.
+func AirspeedDelta(tags map[int]*KLVTag) (float64, bool) {
+	speeds, ok := tagFloats(tags, 8, 9)
+	if !ok {
+		return 0, false
+	}
+	return speeds[0] - speeds[1], true
+}
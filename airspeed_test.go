@@ -0,0 +1,18 @@
+package klvparser
+
+import "testing"
+
+func TestAirspeedDelta(t *testing.T) {
+	tags := decodeOne(t, pkt(true, item(8, 60), item(9, 52)))
+	if delta, ok := AirspeedDelta(tags); !ok || delta != 8 {
+		t.Errorf("AirspeedDelta = %v, %v, want 8", delta, ok)
+	}
+	if tags[8].Unit != "m/s" || tags[9].Unit != "m/s" {
+		t.Errorf("airspeed units = %q, %q, want m/s", tags[8].Unit, tags[9].Unit)
+	}
+
+	delete(tags, 9)
+	if _, ok := AirspeedDelta(tags); ok {
+		t.Error("AirspeedDelta without the indicated airspeed is ok")
+	}
+}
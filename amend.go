@@ -0,0 +1,45 @@
+package klvparser
+
+import "sort"
+
+// processAmendSet decodes an Amend Local Set (tag 101). Its items are MISB ST 0601
+// tags whose values replace those of the frame being amended; they are decoded with
+// the regular tag decoders and stored as the tag's children, keyed by the tag they
+// target. The hex dump of the set is kept as the tag value.
+func (p *KLVParser) processAmendSet(tag int, value []byte) {
+	meta := p.tagMeta[tag]
+	if meta == nil {
+		return
+	}
+	meta.Value = extractHex(value)
+	meta.Children = p.decodeEmbeddedSet(tag, value)
+}
+
+// AmendmentTargets returns the tags replaced by a decoded Amend Local Set (tag 101).
+func AmendmentTargets(amend *KLVTag) []int {
+	if amend == nil {
+		return nil
+	}
+	targets := make([]int, 0, len(amend.Children))
+	for tag := range amend.Children {
+		targets = append(targets, tag)
+	}
+	sort.Ints(targets)
+	return targets
+}
+
+// ApplyAmendment returns a new map holding the tags of base with those replaced
+// by a decoded Amend Local Set (tag 101). base is not modified.
+func ApplyAmendment(base map[int]*KLVTag, amend *KLVTag) map[int]*KLVTag {
+	merged := make(map[int]*KLVTag, len(base))
+	for tag, data := range base {
+		merged[tag] = data
+	}
+	if amend == nil {
+		return merged
+	}
+	for tag, data := range amend.Children {
+		merged[tag] = data
+	}
+	return merged
+}
@@ -0,0 +1,26 @@
+package klvparser
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestApplyAmendment(t *testing.T) {
+	// The amendment replaces the sensor latitude of 22.5° with 45°.
+	amend := item(13, u32(0x40000000)...)
+	tags := decodeOne(t, pkt(true, item(13, u32(0x20000000)...), item(14, u32(0x20000000)...), item(101, amend...)))
+	if targets := AmendmentTargets(tags[101]); !reflect.DeepEqual(targets, []int{13}) {
+		t.Fatalf("AmendmentTargets = %v, want [13]", targets)
+	}
+	merged := ApplyAmendment(tags, tags[101])
+	if got := merged[13].Value.(float64); math.Abs(got-45) > 1e-6 {
+		t.Errorf("amended latitude = %v, want 45", got)
+	}
+	if merged[14] != tags[14] {
+		t.Error("longitude not carried over from the base frame")
+	}
+	if got := tags[13].Value.(float64); math.Abs(got-22.5) > 1e-6 {
+		t.Errorf("base latitude = %v after ApplyAmendment, want 22.5", got)
+	}
+}
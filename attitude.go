@@ -0,0 +1,38 @@
+package klvparser
+
+import "math"
+
+// PlatformAttitudeQuaternion converts the platform heading (tag 5), pitch, and roll
+// into a unit quaternion rotating the local North-East-Down frame onto the body
+// frame, using the aerospace yaw-pitch-roll (Z-Y-X) sequence. Pitch and roll are
+// taken from the full-resolution tags 90 and 91 when present, falling back to
+// tags 6 and 7. ok is false when any of the three angles is missing.
+func PlatformAttitudeQuaternion(tags map[int]*KLVTag) (w, x, y, z float64, ok bool) {
+	heading, headingOK := tagFloat(tags, 5)
+	pitch, pitchOK := firstTagFloat(tags, 90, 6)
+	roll, rollOK := firstTagFloat(tags, 91, 7)
+	if !headingOK || !pitchOK || !rollOK {
+		return 0, 0, 0, 0, false
+	}
+
+	const halfDegree = math.Pi / 360
+	sy, cy := math.Sincos(heading * halfDegree)
+	sp, cp := math.Sincos(pitch * halfDegree)
+	sr, cr := math.Sincos(roll * halfDegree)
+
+	w = cr*cp*cy + sr*sp*sy
+	x = sr*cp*cy - cr*sp*sy
+	y = cr*sp*cy + sr*cp*sy
+	z = cr*cp*sy - sr*sp*cy
+	return w, x, y, z, true
+}
+
+// firstTagFloat returns the value of the first of the given tags that holds a number.
+func firstTagFloat(tags map[int]*KLVTag, ids ...int) (float64, bool) {
+	for _, id := range ids {
+		if v, ok := tagFloat(tags, id); ok {
+			return v, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,42 @@
+package klvparser
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPlatformAttitudeQuaternionFullAngles(t *testing.T) {
+	full := func(degrees float64) []byte {
+		return u32(uint32(int32(math.Round(degrees * (1<<31 - 1) / 90))))
+	}
+	tags := decodeOne(t, pkt(true,
+		item(5, u16(0)...),
+		item(90, full(0)...),
+		item(91, full(-30)...),
+	))
+	if roll := tags[91].Value.(float64); math.Abs(roll+30) > 1e-6 {
+		t.Fatalf("roll = %v, want -30", roll)
+	}
+	w, x, y, z, ok := PlatformAttitudeQuaternion(tags)
+	if !ok {
+		t.Fatal("no quaternion from heading and full pitch and roll")
+	}
+	want := [4]float64{math.Cos(-15 * math.Pi / 180), math.Sin(-15 * math.Pi / 180), 0, 0}
+	for i, got := range [4]float64{w, x, y, z} {
+		if math.Abs(got-want[i]) > 1e-6 {
+			t.Errorf("quaternion = %v, want %v", [4]float64{w, x, y, z}, want)
+			break
+		}
+	}
+}
+
+func TestFullSideslipAngleRange(t *testing.T) {
+	encoded, err := Encode(map[int]*KLVTag{93: {Value: -170.0, Unit: "°"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tags := decodeOne(t, encoded)
+	if got := tags[93].Value.(float64); math.Abs(got+170) > 1e-6 {
+		t.Errorf("sideslip = %v, want -170", got)
+	}
+}
@@ -0,0 +1,43 @@
+package klvparser
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+)
+
+// maxBase64LineLength bounds a single line of base64 input.
+const maxBase64LineLength = 1 << 20
+
+// ParseBase64Lines reads r line by line, decodes each line as standard base64,
+// and feeds the decoded bytes through a new parser delivering packets to callback.
+// Surrounding whitespace is ignored and blank lines are skipped. It stops at the
+// first line that is not valid base64.
+func ParseBase64Lines(r io.Reader, callback func(map[int]*KLVTag)) error {
+	return NewKLVParser(callback).ParseBase64Lines(r)
+}
+
+// ParseBase64Lines is like the package-level ParseBase64Lines but uses p and its options.
+func (p *KLVParser) ParseBase64Lines(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxBase64LineLength)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		data := make([]byte, base64.StdEncoding.DecodedLen(len(line)))
+		n, err := base64.StdEncoding.Decode(data, line)
+		if err != nil {
+			return fmt.Errorf("invalid base64 on line %d: %w", lineNumber, err)
+		}
+		if err := p.ProcessChunk(data[:n]); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
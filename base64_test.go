@@ -0,0 +1,28 @@
+package klvparser
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestParseBase64Lines(t *testing.T) {
+	first := base64.StdEncoding.EncodeToString(pkt(true, item(5, u16(1)...)))
+	second := base64.StdEncoding.EncodeToString(pkt(true, item(5, u16(2)...)))
+	input := "  " + first + "\r\n\n\t\n" + second + "  \n"
+	var raws [][]byte
+	err := ParseBase64Lines(strings.NewReader(input), func(tags map[int]*KLVTag) {
+		raws = append(raws, tags[5].Raw)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(raws) != 2 || raws[0][1] != 1 || raws[1][1] != 2 {
+		t.Fatalf("decoded headings % x, want 0001 and 0002", raws)
+	}
+
+	if err := ParseBase64Lines(strings.NewReader(first+"\n!!!\n"), func(map[int]*KLVTag) {}); err == nil ||
+		!strings.Contains(err.Error(), "line 2") {
+		t.Errorf("error = %v, want invalid base64 on line 2", err)
+	}
+}
@@ -0,0 +1,48 @@
+package klvparser
+
+import (
+	"bytes"
+	"sync"
+)
+
+// DecodeBatch decodes independent, pre-framed packets across a pool of workers and
+// returns the tags of each packet in input order. The entry of a packet that does
+// not start with the MISB ST 0601 key or fails to decode is nil.
+func DecodeBatch(packets [][]byte, workers int) []map[int]*KLVTag {
+	if workers < 1 {
+		workers = 1
+	}
+	results := make([]map[int]*KLVTag, len(packets))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var decoded map[int]*KLVTag
+			parser := NewKLVParser(func(tags map[int]*KLVTag) {
+				decoded = tags
+			})
+			for i := range jobs {
+				packet := packets[i]
+				if !bytes.HasPrefix(packet, MISB0601UL) {
+					continue
+				}
+				decoded = nil
+				if err := parser.parseKLVPacket(packet); err != nil {
+					parser.reportError(0, err)
+					continue
+				}
+				results[i] = decoded
+			}
+		}()
+	}
+
+	for i := range packets {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,32 @@
+package klvparser
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDecodeBatch(t *testing.T) {
+	packets := make([][]byte, 1000)
+	for i := range packets {
+		packets[i] = pkt(true, item(2, u64(uint64(i))...), item(5, u16(uint16(i*60))...))
+	}
+	packets[500] = []byte("not a packet")
+	results := DecodeBatch(packets, 4)
+	if len(results) != len(packets) {
+		t.Fatalf("got %d results for %d packets", len(results), len(packets))
+	}
+	for i, tags := range results {
+		if i == 500 {
+			if tags != nil {
+				t.Errorf("packet 500 without a key decoded to %v", tags)
+			}
+			continue
+		}
+		if tags[2].Value != float64(i) {
+			t.Fatalf("result %d holds the time stamp of packet %v", i, tags[2].Value)
+		}
+		if want := float64(uint16(i*60)) * 360 / 65535; math.Abs(tags[5].Value.(float64)-want) > 1e-9 {
+			t.Fatalf("result %d: heading %v, want %v", i, tags[5].Value, want)
+		}
+	}
+}
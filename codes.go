@@ -0,0 +1,202 @@
+package klvparser
+
+import (
+	"fmt"
+	"sort"
+)
+
+// tagCodes maps each MISB ST 0601 tag to a stable short code.
+// Codes are intended for downstream systems that key records by a fixed
+// identifier rather than by tag number or display name.
+var tagCodes = map[int]string{
+	1:   "CHECKSUM",
+	2:   "PRECISION_TS",
+	3:   "MISSION_ID",
+	4:   "TAIL_NUM",
+	5:   "PLAT_HDG",
+	6:   "PLAT_PITCH",
+	7:   "PLAT_ROLL",
+	8:   "PLAT_TAS",
+	9:   "PLAT_IAS",
+	10:  "PLAT_DESIG",
+	11:  "IMG_SRC_SENSOR",
+	12:  "IMG_COORD_SYS",
+	13:  "SENS_LAT",
+	14:  "SENS_LON",
+	15:  "SENS_ALT",
+	16:  "SENS_HFOV",
+	17:  "SENS_VFOV",
+	18:  "SENS_REL_AZ",
+	19:  "SENS_REL_EL",
+	20:  "SENS_REL_ROLL",
+	21:  "SLANT_RANGE",
+	22:  "TGT_WIDTH",
+	23:  "FC_LAT",
+	24:  "FC_LON",
+	25:  "FC_ELEV",
+	26:  "OFS_LAT_1",
+	27:  "OFS_LON_1",
+	28:  "OFS_LAT_2",
+	29:  "OFS_LON_2",
+	30:  "OFS_LAT_3",
+	31:  "OFS_LON_3",
+	32:  "OFS_LAT_4",
+	33:  "OFS_LON_4",
+	34:  "ICING",
+	35:  "WIND_DIR",
+	36:  "WIND_SPEED",
+	37:  "STATIC_PRESS",
+	38:  "DENSITY_ALT",
+	39:  "OAT",
+	40:  "TGT_LAT",
+	41:  "TGT_LON",
+	42:  "TGT_ELEV",
+	43:  "TGT_GATE_W",
+	44:  "TGT_GATE_H",
+	45:  "TGT_CE90",
+	46:  "TGT_LE90",
+	47:  "GENERIC_FLAGS",
+	48:  "SECURITY_LS",
+	49:  "DIFF_PRESS",
+	50:  "PLAT_AOA",
+	51:  "PLAT_VSPEED",
+	52:  "PLAT_SIDESLIP",
+	53:  "AIRFIELD_BARO",
+	54:  "AIRFIELD_ELEV",
+	55:  "REL_HUMIDITY",
+	56:  "PLAT_GND_SPEED",
+	57:  "GROUND_RANGE",
+	58:  "PLAT_FUEL",
+	59:  "PLAT_CALLSIGN",
+	60:  "WEAPON_LOAD",
+	61:  "WEAPON_FIRED",
+	62:  "LASER_PRF",
+	63:  "SENS_FOV_NAME",
+	64:  "PLAT_MAG_HDG",
+	65:  "LS_VERSION",
+	66:  "TGT_COV_MATRIX",
+	67:  "ALT_PLAT_LAT",
+	68:  "ALT_PLAT_LON",
+	69:  "ALT_PLAT_ALT",
+	70:  "ALT_PLAT_NAME",
+	71:  "ALT_PLAT_HDG",
+	72:  "EVENT_START",
+	73:  "RVT_LS",
+	74:  "VMTI_LS",
+	75:  "SENS_HAE",
+	76:  "ALT_PLAT_HAE",
+	77:  "OP_MODE",
+	78:  "FC_HAE",
+	79:  "SENS_VEL_N",
+	80:  "SENS_VEL_E",
+	81:  "IMG_HORIZON",
+	82:  "CRN_LAT_1",
+	83:  "CRN_LON_1",
+	84:  "CRN_LAT_2",
+	85:  "CRN_LON_2",
+	86:  "CRN_LAT_3",
+	87:  "CRN_LON_3",
+	88:  "CRN_LAT_4",
+	89:  "CRN_LON_4",
+	90:  "PLAT_PITCH_FULL",
+	91:  "PLAT_ROLL_FULL",
+	92:  "PLAT_AOA_FULL",
+	93:  "PLAT_SIDESLIP_FULL",
+	94:  "MIIS_ID",
+	95:  "SAR_LS",
+	96:  "TGT_WIDTH_EXT",
+	97:  "RANGE_IMG_LS",
+	98:  "GEOREG_LS",
+	99:  "COMPOSITE_LS",
+	100: "SEGMENT_LS",
+	101: "AMEND_LS",
+	102: "SDCC_FLP",
+	103: "DENSITY_ALT_EXT",
+	104: "SENS_HAE_EXT",
+	105: "ALT_PLAT_HAE_EXT",
+	106: "STREAM_DESIG",
+	107: "OP_BASE",
+	108: "BCAST_SRC",
+	109: "RANGE_RECOVERY",
+	110: "TIME_AIRBORNE",
+	111: "PROP_RPM",
+	112: "PLAT_COURSE",
+	113: "ALT_AGL",
+	114: "RADAR_ALT",
+	115: "CTRL_CMD",
+	116: "CTRL_CMD_VERIFY",
+	117: "SENS_AZ_RATE",
+	118: "SENS_EL_RATE",
+	119: "SENS_ROLL_RATE",
+	120: "STORAGE_PCT_FULL",
+	121: "WAVELENGTHS_ACTIVE",
+	122: "COUNTRY_CODES",
+	123: "NAVSATS_IN_VIEW",
+	124: "POS_METHOD",
+	125: "PLAT_STATUS",
+	126: "SENS_CTRL_MODE",
+	127: "SENS_FRAME_RATE",
+	128: "WAVELENGTHS_LIST",
+	129: "TGT_ID",
+	130: "AIRBASE_LOCS",
+	131: "TAKEOFF_TIME",
+	132: "TX_FREQ",
+	133: "STORAGE_CAPACITY",
+	134: "ZOOM_PCT",
+	135: "COMMS_METHOD",
+	136: "LEAP_SECONDS",
+	137: "CORRECTION_OFFSET",
+	138: "PAYLOAD_LIST",
+	139: "ACTIVE_PAYLOADS",
+	140: "WEAPONS_STORES",
+	141: "WAYPOINT_LIST",
+	142: "VIEW_DOMAIN",
+	143: "SUBSTREAM_ID",
+}
+
+// TagCode returns the short code for a tag, or "TAG_<n>" if the tag has none.
+func TagCode(tag int) string {
+	if code, ok := tagCodes[tag]; ok {
+		return code
+	}
+	return fmt.Sprintf("TAG_%d", tag)
+}
+
+// RecordsByCode returns the decoded values of the given tags keyed by their short code.
+// Tags without a decoded value are omitted.
+func RecordsByCode(tags map[int]*KLVTag) map[string]any {
+	records := make(map[string]any, len(tags))
+	for tag, data := range tags {
+		if data == nil || data.Value == nil {
+			continue
+		}
+		records[TagCode(tag)] = data.Value
+	}
+	return records
+}
+
+// FrameFields returns the decoded values of the given tags as alternating short
+// codes and values, in ascending tag order, ready to pass to structured loggers
+// such as slog, zap or logrus. Tags without a decoded value are omitted, and
+// string values are passed as strings rather than pointers.
+func FrameFields(tags map[int]*KLVTag) []any {
+	ids := make([]int, 0, len(tags))
+	for tag, data := range tags {
+		if data != nil && data.Value != nil {
+			ids = append(ids, tag)
+		}
+	}
+	sort.Ints(ids)
+	fields := make([]any, 0, 2*len(ids))
+	for _, tag := range ids {
+		value := tags[tag].Value
+		if s, ok := value.(*string); ok {
+			if s == nil {
+				continue
+			}
+			value = *s
+		}
+		fields = append(fields, TagCode(tag), value)
+	}
+	return fields
+}
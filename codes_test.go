@@ -0,0 +1,42 @@
+package klvparser
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestRecordsByCode(t *testing.T) {
+	tags := decodeOne(t, pkt(true, item(13, u32(0x10000000)...), item(5, u16(0x8000)...)))
+	records := RecordsByCode(tags)
+	codes := make([]string, 0, len(records))
+	for code := range records {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	// The checksum closing the packet is decoded as tag 1.
+	if want := []string{"CHECKSUM", "PLAT_HDG", "SENS_LAT"}; !reflect.DeepEqual(codes, want) {
+		t.Fatalf("codes = %v, want %v", codes, want)
+	}
+	if records["SENS_LAT"] != tags[13].Value {
+		t.Errorf("SENS_LAT = %v, want %v", records["SENS_LAT"], tags[13].Value)
+	}
+}
+
+func TestTagCodeFallback(t *testing.T) {
+	if code := TagCode(13); code != "SENS_LAT" {
+		t.Errorf("TagCode(13) = %q, want SENS_LAT", code)
+	}
+	if code := TagCode(250); code != "TAG_250" {
+		t.Errorf("TagCode(250) = %q, want TAG_250", code)
+	}
+}
+
+func TestFrameFields(t *testing.T) {
+	stores := "AB"
+	fields := FrameFields(map[int]*KLVTag{9: {Value: 3.0}, 5: {Value: 1.0}, 140: {Value: &stores}, 7: {}})
+	// Tag 7 has no value and is left out.
+	if want := []any{"PLAT_HDG", 1.0, "PLAT_IAS", 3.0, "WEAPONS_STORES", "AB"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("FrameFields = %v, want %v", fields, want)
+	}
+}
@@ -0,0 +1,84 @@
+package klvparser
+
+import (
+	"fmt"
+	"math"
+)
+
+// maxGeoidSeparation bounds the difference between a height above the ellipsoid and
+// the matching height above mean sea level. The geoid undulation stays within about
+// -107 to +85 meters worldwide.
+const maxGeoidSeparation = 110.0
+
+// cornerTolerance is the largest difference, in degrees, tolerated between a corner
+// derived from the offset corners (26-33) and the matching full corner (82-89).
+const cornerTolerance = 0.001
+
+// checkConsistency cross-checks related tags of a packet and reports contradictions.
+func (p *KLVParser) checkConsistency(parsedTags map[int]*KLVTag) {
+	p.checkFrameCenterHeight(parsedTags)
+	p.checkCorners(parsedTags)
+}
+
+// checkFrameCenterHeight compares Frame Center Height Above Ellipsoid (tag 78)
+// against Frame Center Elevation (tag 25).
+func (p *KLVParser) checkFrameCenterHeight(parsedTags map[int]*KLVTag) {
+	elevation, ok := tagFloat(parsedTags, 25)
+	if !ok {
+		return
+	}
+	height, ok := tagFloat(parsedTags, 78)
+	if !ok {
+		return
+	}
+	if math.Abs(height-elevation) > maxGeoidSeparation {
+		p.reportError(78, fmt.Errorf("%w: frame center height above ellipsoid %.1f m differs from elevation %.1f m by more than %.0f m",
+			ErrInconsistentTags, height, elevation, maxGeoidSeparation))
+	}
+}
+
+// checkCorners compares the offset corners, applied to the frame center, against
+// the full corners when both representations are present.
+func (p *KLVParser) checkCorners(parsedTags map[int]*KLVTag) {
+	centerLat, ok := tagFloat(parsedTags, 23)
+	if !ok {
+		return
+	}
+	centerLon, ok := tagFloat(parsedTags, 24)
+	if !ok {
+		return
+	}
+	for corner := 0; corner < 4; corner++ {
+		// Latitude and longitude tags alternate: 26/27 and 82/83 hold corner 1, and so on.
+		for i, center := range []float64{centerLat, centerLon} {
+			offsetTag := 26 + 2*corner + i
+			fullTag := 82 + 2*corner + i
+			offset, ok := tagFloat(parsedTags, offsetTag)
+			if !ok {
+				continue
+			}
+			full, ok := tagFloat(parsedTags, fullTag)
+			if !ok {
+				continue
+			}
+			if math.Abs(center+offset-full) > cornerTolerance {
+				p.reportError(fullTag, fmt.Errorf("%w: tag %d is %f but frame center plus tag %d gives %f",
+					ErrInconsistentTags, fullTag, full, offsetTag, center+offset))
+			}
+		}
+	}
+}
+
+// tagFloat returns the numeric value of a tag, if present, in degrees or meters
+// whatever the Units it was decoded with.
+func tagFloat(tags map[int]*KLVTag, tag int) (float64, bool) {
+	data, ok := tags[tag]
+	if !ok || data == nil {
+		return 0, false
+	}
+	value, ok := data.Value.(float64)
+	if !ok || math.IsNaN(value) {
+		return 0, false
+	}
+	return toBaseUnit(data.Unit, value), true
+}
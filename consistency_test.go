@@ -0,0 +1,68 @@
+package klvparser
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// consistencyErrors decodes a packet and returns the errors it reported.
+func consistencyErrors(t *testing.T, packet []byte) (map[int]*KLVTag, []error) {
+	t.Helper()
+	var errs []error
+	var got map[int]*KLVTag
+	p := NewKLVParser(func(tags map[int]*KLVTag) { got = tags })
+	p.OnError = func(tag int, err error) { errs = append(errs, err) }
+	if err := p.ProcessChunk(packet); err != nil {
+		t.Fatal(err)
+	}
+	return got, errs
+}
+
+func TestFrameCenterHeight(t *testing.T) {
+	tests := []struct {
+		name              string
+		elevation, height float64
+		inconsistent      bool
+	}{
+		{"within the geoid separation", 100, 130, false},
+		{"beyond the geoid separation", 100, 100 + maxGeoidSeparation + 50, true},
+	}
+	for _, tt := range tests {
+		tags, errs := consistencyErrors(t, pkt(true,
+			item(25, tagEncodings[25].encode(tt.elevation)...),
+			item(78, tagEncodings[78].encode(tt.height)...)))
+		if got, _ := tags[78].Value.(float64); math.Abs(got-tt.height) > 0.5 {
+			t.Errorf("%s: frame center height = %v, want %v", tt.name, tags[78].Value, tt.height)
+		}
+		inconsistent := len(errs) == 1 && errors.Is(errs[0], ErrInconsistentTags)
+		if inconsistent != tt.inconsistent || len(errs) > 1 {
+			t.Errorf("%s: errors %v, want inconsistent %v", tt.name, errs, tt.inconsistent)
+		}
+	}
+}
+
+func TestCorners(t *testing.T) {
+	tests := []struct {
+		name         string
+		lat, lon     float64
+		inconsistent bool
+	}{
+		{"matching full corner", 10.05, 20.05, false},
+		{"full corner elsewhere", 10.05, 21, true},
+	}
+	for _, tt := range tests {
+		// Frame center at 10°, 20° with corner 1 offset by 0.05°, 0.05°.
+		_, errs := consistencyErrors(t, pkt(true,
+			item(23, tagEncodings[23].encode(10)...),
+			item(24, tagEncodings[24].encode(20)...),
+			item(26, tagEncodings[26].encode(0.05)...),
+			item(27, tagEncodings[27].encode(0.05)...),
+			item(82, tagEncodings[82].encode(tt.lat)...),
+			item(83, tagEncodings[83].encode(tt.lon)...)))
+		inconsistent := len(errs) == 1 && errors.Is(errs[0], ErrInconsistentTags)
+		if inconsistent != tt.inconsistent || len(errs) > 1 {
+			t.Errorf("%s: errors %v, want inconsistent %v", tt.name, errs, tt.inconsistent)
+		}
+	}
+}
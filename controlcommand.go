@@ -0,0 +1,40 @@
+package klvparser
+
+import "time"
+
+// ControlCommand is a decoded Control Command (tag 115).
+type ControlCommand struct {
+	ID      int       // Command identifier
+	Command string    // Command text
+	Time    time.Time // Time the command was issued; zero when not carried
+}
+
+// extractControlCommand decodes a Control Command (tag 115): a pack of BER
+// length-prefixed fields holding the BER-OID command ID, the command text, and
+// optionally the command time in microseconds since the epoch, as for the
+// Precision Time Stamp (tag 2). It returns nil when the value does not match
+// this layout.
+func (p *KLVParser) extractControlCommand(value []byte) *ControlCommand {
+	_, idField, index := p.extractTagValue(value, 0)
+	if idField == nil {
+		return nil
+	}
+	id, n := extractBEROID(idField)
+	if n != len(idField) {
+		return nil
+	}
+	_, text, index := p.extractTagValue(value, index)
+	if text == nil {
+		return nil
+	}
+	command := &ControlCommand{ID: int(id), Command: string(text)}
+	if index == len(value) {
+		return command
+	}
+	_, timeField, index := p.extractTagValue(value, index)
+	if len(timeField) != 8 || index != len(value) {
+		return nil
+	}
+	command.Time = time.UnixMicro(int64(*extractUint64(timeField))).UTC()
+	return command
+}
@@ -0,0 +1,26 @@
+package klvparser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestControlCommand(t *testing.T) {
+	// The ID 129 takes two BER-OID bytes.
+	value := append([]byte{2, 0x81, 0x01, 3, 'a', 'b', 'c', 8}, u64(1700000000123456)...)
+	command, ok := decodeOne(t, pkt(true, item(115, value...)))[115].Value.(ControlCommand)
+	if !ok {
+		t.Fatal("tag 115 is not a ControlCommand")
+	}
+	if command.ID != 129 || command.Command != "abc" {
+		t.Errorf("command = %+v, want ID 129 and text abc", command)
+	}
+	if want := time.Date(2023, 11, 14, 22, 13, 20, 123456000, time.UTC); !command.Time.Equal(want) {
+		t.Errorf("command time = %v, want %v", command.Time, want)
+	}
+
+	command = decodeOne(t, pkt(true, item(115, 1, 2, 1, 'x')))[115].Value.(ControlCommand)
+	if command.ID != 2 || command.Command != "x" || !command.Time.IsZero() {
+		t.Errorf("command without a time = %+v", command)
+	}
+}
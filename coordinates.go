@@ -0,0 +1,72 @@
+package klvparser
+
+import (
+	"fmt"
+	"math"
+)
+
+// latitudeTags and longitudeTags list the tags holding absolute latitudes and longitudes.
+var (
+	latitudeTags  = []int{13, 23, 40, 67, 82, 84, 86, 88}
+	longitudeTags = []int{14, 24, 41, 68, 83, 85, 87, 89}
+)
+
+// SetNormalizeCoordinates enables normalization of decoded coordinates: latitudes
+// are clamped to [-90, 90] and longitudes are wrapped into [-180, 180).
+func (p *KLVParser) SetNormalizeCoordinates(normalize bool) {
+	p.normalizeCoords = normalize
+}
+
+// SetDetectWraparound enables detection of longitudes that jump across the
+// antimeridian between consecutive packets, such as +179.999 followed by -179.999.
+// Each jump is reported through OnError with ErrLongitudeWraparound.
+func (p *KLVParser) SetDetectWraparound(detect bool) {
+	p.detectWrap = detect
+	p.lastLongitudes = nil
+}
+
+// normalizeCoordinates clamps latitudes and wraps longitudes when enabled.
+func (p *KLVParser) normalizeCoordinates(parsedTags map[int]*KLVTag) {
+	if !p.normalizeCoords {
+		return
+	}
+	for _, tag := range latitudeTags {
+		if lat, ok := tagFloat(parsedTags, tag); ok {
+			parsedTags[tag].Value = fromBaseUnit(parsedTags[tag].Unit, math.Max(-90, math.Min(90, lat)))
+		}
+	}
+	for _, tag := range longitudeTags {
+		if lon, ok := tagFloat(parsedTags, tag); ok {
+			parsedTags[tag].Value = fromBaseUnit(parsedTags[tag].Unit, wrapLongitude(lon))
+		}
+	}
+}
+
+// wrapLongitude wraps a longitude into [-180, 180).
+func wrapLongitude(lon float64) float64 {
+	wrapped := math.Mod(lon+180, 360)
+	if wrapped < 0 {
+		wrapped += 360
+	}
+	return wrapped - 180
+}
+
+// checkWraparound reports longitudes that crossed the antimeridian since the previous packet.
+func (p *KLVParser) checkWraparound(parsedTags map[int]*KLVTag) {
+	if !p.detectWrap {
+		return
+	}
+	if p.lastLongitudes == nil {
+		p.lastLongitudes = make(map[int]float64)
+	}
+	for _, tag := range longitudeTags {
+		lon, ok := tagFloat(parsedTags, tag)
+		if !ok {
+			continue
+		}
+		if last, ok := p.lastLongitudes[tag]; ok && math.Abs(lon-last) > 180 {
+			p.reportError(tag, fmt.Errorf("%w: tag %d went from %f to %f", ErrLongitudeWraparound, tag, last, lon))
+		}
+		p.lastLongitudes[tag] = lon
+	}
+}
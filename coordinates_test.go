@@ -0,0 +1,51 @@
+package klvparser
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWrapLongitude(t *testing.T) {
+	for _, tt := range []struct{ lon, want float64 }{
+		{0, 0},
+		{360, 0},
+		{-360, 0},
+		{180, -180},
+		{-180, -180},
+		{179.5, 179.5},
+		{190, -170},
+		{-190, 170},
+		{540, -180},
+	} {
+		if got := wrapLongitude(tt.lon); got != tt.want {
+			t.Errorf("wrapLongitude(%v) = %v, want %v", tt.lon, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeCoordinates(t *testing.T) {
+	var tags map[int]*KLVTag
+	p := NewKLVParser(func(m map[int]*KLVTag) { tags = m })
+	p.SetNormalizeCoordinates(true)
+	if err := p.ProcessChunk(pkt(true, item(13, u32(0x7FFFFFFF)...), item(14, u32(0x7FFFFFFF)...))); err != nil {
+		t.Fatal(err)
+	}
+	if tags[13].Value != 90.0 || tags[14].Value != -180.0 {
+		t.Errorf("position = %v, %v, want 90, -180", tags[13].Value, tags[14].Value)
+	}
+}
+
+func TestDetectWraparound(t *testing.T) {
+	var errs []error
+	p := NewKLVParser(func(map[int]*KLVTag) {})
+	p.OnError = func(tag int, err error) { errs = append(errs, err) }
+	p.SetDetectWraparound(true)
+	for _, lon := range []float64{179.999, -179.999, -179.998} {
+		if err := p.ProcessChunk(pkt(true, item(14, tagEncodings[14].encode(lon)...))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrLongitudeWraparound) {
+		t.Errorf("errors = %v, want one ErrLongitudeWraparound", errs)
+	}
+}
@@ -0,0 +1,86 @@
+package klvparser
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"sync"
+)
+
+// CSVWriter writes decoded frames to an io.Writer as CSV, one row per frame and
+// one column per selected tag, for loading a stream into a spreadsheet. The first
+// row holds the tag names.
+type CSVWriter struct {
+	mu            sync.Mutex
+	w             *csv.Writer
+	tags          []int
+	headerWritten bool
+}
+
+// NewCSVWriter returns a writer of CSV rows to w with a column for each of tags, in order.
+func NewCSVWriter(w io.Writer, tags []int) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w), tags: append([]int(nil), tags...)}
+}
+
+// WriteFrame writes a decoded frame as a single row, preceded by the header row
+// on first use. The cell of a tag missing from the frame, or without a value, is
+// empty so the columns stay aligned.
+func (c *CSVWriter) WriteFrame(tags map[int]*KLVTag) error {
+	row := make([]string, len(c.tags))
+	for i, tag := range c.tags {
+		if data := tags[tag]; data != nil {
+			row[i] = csvCell(data.Value)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.headerWritten {
+		header := make([]string, len(c.tags))
+		for i, tag := range c.tags {
+			header[i] = csvHeader(tag)
+		}
+		if err := c.w.Write(header); err != nil {
+			return err
+		}
+		c.headerWritten = true
+	}
+	if err := c.w.Write(row); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// csvHeader returns the column name of a tag: its name, or "Tag n" for a tag
+// MISB ST 0601 does not define.
+func csvHeader(tag int) string {
+	if definition, ok := tagDefinitions[tag]; ok {
+		return definition.name
+	}
+	return "Tag " + strconv.Itoa(tag)
+}
+
+// csvCell formats a tag value as a cell. Numbers are written in full precision;
+// NaN and absent values give an empty cell.
+func csvCell(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		if math.IsNaN(v) {
+			return ""
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	case *string:
+		if v == nil {
+			return ""
+		}
+		return *v
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,29 @@
+package klvparser
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestCSVWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := NewCSVWriter(&out, []int{3, 5, 13, 250})
+	hex := "0A0B"
+	frames := []map[int]*KLVTag{
+		{3: {Value: "Mission, one"}, 5: {Value: 90.5}, 13: {Value: 51.25}},
+		// A missing tag, a NaN, a tag without a value and a hex dump.
+		{5: {Value: math.NaN()}, 13: {}, 250: {Value: &hex}},
+	}
+	for _, frame := range frames {
+		if err := w.WriteFrame(frame); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := "Mission ID,Platform Heading Angle,Sensor Latitude,Tag 250\n" +
+		"\"Mission, one\",90.5,51.25,\n" +
+		",,,0A0B\n"
+	if got := out.String(); got != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", got, want)
+	}
+}
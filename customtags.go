@@ -0,0 +1,26 @@
+package klvparser
+
+// RegisterTag installs a decoder for tag id, such as a vendor or experimental
+// tag that MISB ST 0601 does not define. The tag is delivered as name, with the
+// handler's result as its Value; no bounds are checked. A registered handler
+// takes precedence over the built-in decoding, including for a defined tag,
+// whose metadata it replaces. Keys are a single byte, so id ranges from 1 to 255.
+func (p *KLVParser) RegisterTag(id int, name string, handler func([]byte) interface{}) {
+	if p.handlers == nil {
+		p.handlers = make(map[int]func([]byte) interface{})
+	}
+	p.handlers[id] = handler
+	p.tagMeta[id] = &KLVTag{Name: name}
+}
+
+// processCustomTag decodes a tag with its registered handler, reporting whether
+// one was registered.
+func (p *KLVParser) processCustomTag(tag int, value []byte) bool {
+	handler, ok := p.handlers[tag]
+	if !ok {
+		return false
+	}
+	meta := p.tagMeta[tag]
+	meta.Value = handler(value)
+	return true
+}
@@ -0,0 +1,24 @@
+package klvparser
+
+import "testing"
+
+func TestRegisterTag(t *testing.T) {
+	var tags map[int]*KLVTag
+	p := NewKLVParser(func(m map[int]*KLVTag) { tags = m })
+	p.OnError = func(tag int, err error) { t.Errorf("tag %d: %v", tag, err) }
+	p.RegisterTag(200, "Vendor Blob", func(b []byte) interface{} { return len(b) })
+	// Tag 3 (Mission ID) is defined by the standard; the handler replaces its decoding.
+	p.RegisterTag(3, "Mission Override", func(b []byte) interface{} { return "x" + string(b) })
+	if err := p.ProcessChunk(pkt(true, item(200, 1, 2, 3), item(3, 'a'))); err != nil {
+		t.Fatal(err)
+	}
+	if tags[200].Name != "Vendor Blob" || tags[200].Value != 3 {
+		t.Errorf("tag 200 = %q %v, want Vendor Blob 3", tags[200].Name, tags[200].Value)
+	}
+	if tags[3].Name != "Mission Override" || tags[3].Value != "xa" {
+		t.Errorf("tag 3 = %q %v, want Mission Override xa", tags[3].Name, tags[3].Value)
+	}
+	if _, err := Encode(tags); err != nil {
+		t.Errorf("Encode with registered tags: %v", err)
+	}
+}
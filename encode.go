@@ -0,0 +1,291 @@
+package klvparser
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// fieldEncoding describes how a decoded float value maps onto its integer wire
+// representation, as the inverse of the extractors: decoded = raw*scale + offset.
+type fieldEncoding struct {
+	size   int // 1, 2, 4, or 8 bytes
+	signed bool
+	scale  float64
+	offset float64
+}
+
+// encode converts value back to its big-endian wire representation, clamping to the
+// representable range. For signed fields the reserved most negative value is never produced.
+func (e fieldEncoding) encode(value float64) []byte {
+	raw := math.Round((value - e.offset) / e.scale)
+	bits := uint(8 * e.size)
+	var min, max float64
+	if e.signed {
+		// The most negative value is reserved as the "out of range" indicator.
+		max = math.Ldexp(1, int(bits)-1) - 1
+		min = -max
+	} else {
+		max = math.Ldexp(1, int(bits)) - 1
+	}
+	raw = math.Max(min, math.Min(max, raw))
+
+	var u uint64
+	if e.signed {
+		u = uint64(int64(raw))
+	} else {
+		u = uint64(raw)
+	}
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, u)
+	return data[8-e.size:]
+}
+
+// tagEncodings holds the wire encoding of the tags decoded to fixed-point floats,
+// matching the decoders in processTag.
+var tagEncodings = map[int]fieldEncoding{
+	5:   {size: 2, scale: 360.0 / 65535.0},
+	6:   {size: 2, signed: true, scale: 40.0 / 65535.0},
+	7:   {size: 2, signed: true, scale: 100.0 / 65534.0},
+	8:   {size: 1, scale: 1},
+	9:   {size: 1, scale: 1},
+	13:  {size: 4, signed: true, scale: latitudeScale},
+	14:  {size: 4, signed: true, scale: longitudeScale},
+	15:  {size: 2, scale: 19900.0 / 65535.0, offset: -900.0},
+	16:  {size: 2, scale: 360.0 / 65535.0},
+	17:  {size: 2, scale: 360.0 / 65535.0},
+	18:  {size: 4, scale: 360.0 / 4294967295.0},
+	19:  {size: 4, signed: true, scale: 360.0 / 4294967294.0},
+	20:  {size: 4, scale: 360.0 / 4294967295.0},
+	21:  {size: 4, scale: 1},
+	22:  {size: 2, scale: 10000.0 / 65535.0},
+	23:  {size: 4, signed: true, scale: latitudeScale},
+	24:  {size: 4, signed: true, scale: longitudeScale},
+	25:  {size: 2, scale: 19900.0 / 65535.0, offset: -900.0},
+	26:  {size: 2, signed: true, scale: 0.075 / 32767.0},
+	27:  {size: 2, signed: true, scale: 0.075 / 32767.0},
+	28:  {size: 2, signed: true, scale: 0.075 / 32767.0},
+	29:  {size: 2, signed: true, scale: 0.075 / 32767.0},
+	30:  {size: 2, signed: true, scale: 0.075 / 32767.0},
+	31:  {size: 2, signed: true, scale: 0.075 / 32767.0},
+	32:  {size: 2, signed: true, scale: 0.075 / 32767.0},
+	33:  {size: 2, signed: true, scale: 0.075 / 32767.0},
+	34:  {size: 1, scale: 1},
+	35:  {size: 2, scale: 4095.0 / 65535.0},
+	36:  {size: 1, scale: 1},
+	37:  {size: 2, scale: 5000.0 / 65535.0},
+	38:  {size: 2, scale: 19900.0 / 65535.0, offset: -900.0},
+	39:  {size: 1, signed: true, scale: 1},
+	40:  {size: 4, signed: true, scale: latitudeScale},
+	41:  {size: 4, signed: true, scale: longitudeScale},
+	42:  {size: 2, scale: 19900.0 / 65535.0, offset: -900.0},
+	43:  {size: 1, scale: 2},
+	44:  {size: 1, scale: 2},
+	45:  {size: 2, scale: 4095.0 / 65535.0},
+	46:  {size: 2, scale: 4095.0 / 65535.0},
+	49:  {size: 2, scale: 5000.0 / 65535.0},
+	50:  {size: 2, signed: true, scale: 40.0 / 65534.0},
+	51:  {size: 2, signed: true, scale: 360.0 / 65534.0},
+	52:  {size: 2, signed: true, scale: 40.0 / 65534.0},
+	53:  {size: 2, scale: 5000.0 / 65535.0},
+	54:  {size: 2, scale: 19900.0 / 65535.0, offset: -900.0},
+	55:  {size: 1, scale: 100.0 / 255.0},
+	56:  {size: 1, scale: 1},
+	57:  {size: 4, scale: 5000000.0 / 4294967295.0},
+	58:  {size: 2, scale: 10000.0 / 65535.0},
+	60:  {size: 2, scale: 1},
+	61:  {size: 1, scale: 1},
+	62:  {size: 2, scale: 1},
+	63:  {size: 1, scale: 1},
+	64:  {size: 2, scale: 360.0 / 65535.0},
+	65:  {size: 1, scale: 1},
+	67:  {size: 4, signed: true, scale: latitudeScale},
+	68:  {size: 4, signed: true, scale: longitudeScale},
+	69:  {size: 2, scale: 19900.0 / 65535.0, offset: -900.0},
+	71:  {size: 2, scale: 360.0 / 65535.0},
+	75:  {size: 2, scale: 1},
+	76:  {size: 2, scale: 1},
+	77:  {size: 1, scale: 1},
+	78:  {size: 2, scale: 19900.0 / 65535.0, offset: -900.0},
+	79:  {size: 2, signed: true, scale: 1},
+	80:  {size: 2, signed: true, scale: 655.34 / 65535.0},
+	82:  {size: 4, signed: true, scale: latitudeScale},
+	83:  {size: 4, signed: true, scale: longitudeScale},
+	84:  {size: 4, signed: true, scale: latitudeScale},
+	85:  {size: 4, signed: true, scale: longitudeScale},
+	86:  {size: 4, signed: true, scale: latitudeScale},
+	87:  {size: 4, signed: true, scale: longitudeScale},
+	88:  {size: 4, signed: true, scale: latitudeScale},
+	89:  {size: 4, signed: true, scale: longitudeScale},
+	90:  {size: 4, signed: true, scale: 90.0 / (1<<31 - 1)},
+	91:  {size: 4, signed: true, scale: 90.0 / (1<<31 - 1)},
+	92:  {size: 4, signed: true, scale: 90.0 / (1<<31 - 1)},
+	93:  {size: 4, signed: true, scale: 180.0 / (1<<31 - 1)},
+	110: {size: 4, scale: 1},
+	111: {size: 4, scale: 1},
+	123: {size: 1, scale: 1},
+	124: {size: 1, scale: 1},
+	125: {size: 1, scale: 1},
+	126: {size: 1, scale: 1},
+	133: {size: 4, scale: 1},
+	136: {size: 4, signed: true, scale: 1},
+	137: {size: 8, signed: true, scale: 1},
+}
+
+// imapbTags lists the tags decoded from length-prefixed IMAPB values onto the
+// range of their tag definition.
+var imapbTags = map[int]bool{
+	96: true, 103: true, 104: true, 105: true, 109: true, 112: true, 113: true,
+	114: true, 117: true, 118: true, 119: true, 120: true, 132: true, 134: true,
+}
+
+// timeTags lists the tags holding microseconds since the epoch.
+var timeTags = map[int]bool{2: true, 72: true, 131: true}
+
+// encodedLSVersion is the UAS Datalink LS version stamped into encoded packets.
+const encodedLSVersion = 19
+
+// Encode builds a MISB ST 0601 packet carrying the telemetry fields, along with
+// the UAS Datalink LS version and a checksum. NaN fields are left out.
+func (t Telemetry) Encode() ([]byte, error) {
+	timestamp := make([]byte, 8)
+	binary.BigEndian.PutUint64(timestamp, t.Timestamp)
+	body := appendItem(nil, 2, timestamp)
+	for i, field := range t.fields() {
+		tag := telemetryTags[i]
+		if math.IsNaN(*field) {
+			continue
+		}
+		encoding, ok := tagEncodings[tag]
+		if !ok {
+			return nil, errors.New("no encoding for telemetry field")
+		}
+		body = appendItem(body, tag, encoding.encode(*field))
+	}
+	body = appendItem(body, 65, []byte{encodedLSVersion})
+	return finishPacket(body), nil
+}
+
+// Encode builds a MISB ST 0601 packet from a tag map, the inverse of decoding it:
+// the key, the BER length, each tag in ascending order and a computed checksum
+// (tag 1), replacing any carried in tags. Numeric values are scaled back to their
+// wire representation, within the precision of the encoding; strings are written
+// as text and hex dumps as the bytes they show. Other values, such as decoded
+// packs and nested sets, are written from the tag's Raw bytes. Tags without a
+// value or NaN are left out. It fails for values that cannot be encoded.
+func Encode(tags map[int]*KLVTag) ([]byte, error) {
+	ids := make([]int, 0, len(tags))
+	for tag := range tags {
+		if tag != 1 && tags[tag] != nil {
+			ids = append(ids, tag)
+		}
+	}
+	sort.Ints(ids)
+
+	var body []byte
+	for _, tag := range ids {
+		if tag < 0 || tag > 0xFF {
+			return nil, fmt.Errorf("%w: %d", ErrUnknownTag, tag)
+		}
+		value, ok, err := encodeTag(tag, tags[tag])
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			body = appendItem(body, tag, value)
+		}
+	}
+	return finishPacket(body), nil
+}
+
+// encodeTag returns the wire value of a tag, or ok false when the tag has no value to encode.
+func encodeTag(tag int, data *KLVTag) (value []byte, ok bool, err error) {
+	switch v := data.Value.(type) {
+	case float64:
+		if math.IsNaN(v) {
+			return nil, false, nil
+		}
+		v = toBaseUnit(data.Unit, v)
+		if timeTags[tag] {
+			micros, ok := data.PrecisionTime()
+			raw := make([]byte, 8)
+			if ok {
+				binary.BigEndian.PutUint64(raw, uint64(micros.UnixMicro()))
+			} else {
+				binary.BigEndian.PutUint64(raw, uint64(v))
+			}
+			return raw, true, nil
+		}
+		if imapbTags[tag] {
+			return encodeIMAPB(v, data.MinValue, data.MaxValue, data.Length), true, nil
+		}
+		if encoding, ok := tagEncodings[tag]; ok {
+			return encoding.encode(v), true, nil
+		}
+	case string:
+		if data.hasLocation && data.Raw != nil {
+			// The position may have been received as a coordinate pack rather than as text.
+			return data.Raw, true, nil
+		}
+		return []byte(v), true, nil
+	case *string:
+		if v != nil {
+			raw, err := hex.DecodeString(*v)
+			if err != nil {
+				return nil, false, fmt.Errorf("tag %d: %w", tag, err)
+			}
+			return raw, true, nil
+		}
+	}
+	if data.Raw != nil {
+		return data.Raw, true, nil
+	}
+	if data.Value == nil {
+		return nil, false, nil
+	}
+	return nil, false, fmt.Errorf("no encoding for tag %d value of type %T", tag, data.Value)
+}
+
+// encodeIMAPB encodes value as a length-prefixed IMAPB value of size bytes mapped
+// onto [min, max], the inverse of extractIMAPBRange.
+func encodeIMAPB(value, min, max float64, size int) []byte {
+	if size < 1 || size > 8 {
+		size = 3
+	}
+	encoding := fieldEncoding{size: size, scale: (max - min) / (math.Ldexp(1, 8*size) - 1), offset: min}
+	return append([]byte{byte(size)}, encoding.encode(value)...)
+}
+
+// appendItem appends a local set item with a 1-byte key and a BER length.
+func appendItem(dst []byte, tag int, value []byte) []byte {
+	dst = append(dst, byte(tag))
+	dst = append(dst, encodeBERLength(len(value))...)
+	return append(dst, value...)
+}
+
+// encodeBERLength encodes a length in BER short or long form.
+func encodeBERLength(length int) []byte {
+	if length < 0x80 {
+		return []byte{byte(length)}
+	}
+	var data []byte
+	for l := length; l > 0; l >>= 8 {
+		data = append([]byte{byte(l)}, data...)
+	}
+	return append([]byte{0x80 | byte(len(data))}, data...)
+}
+
+// finishPacket wraps a local set in the MISB ST 0601 key and length and appends the checksum item.
+func finishPacket(body []byte) []byte {
+	length := len(body) + 4 // checksum key, length, and 2-byte value
+	packet := make([]byte, 0, universalKeyLength+9+length)
+	packet = append(packet, MISB0601UL...)
+	packet = append(packet, encodeBERLength(length)...)
+	packet = append(packet, body...)
+	packet = append(packet, 1, 2)
+	checksum := calculateChecksum(packet)
+	return append(packet, byte(checksum>>8), byte(checksum))
+}
@@ -0,0 +1,74 @@
+package klvparser
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrEmptyPacket reports a packet that declares a value length of zero.
+var ErrEmptyPacket = errors.New("KLV packet has zero length")
+
+// ErrIncompletePacket reports a packet shorter than its length field declares.
+// The stream parser keeps such a packet buffered until the rest arrives.
+var ErrIncompletePacket = errors.New("incomplete KLV packet")
+
+// ErrInvalidLength reports a tag value whose length does not match its encoding.
+var ErrInvalidLength = errors.New("invalid tag value length")
+
+// ErrUnknownTag reports a tag that MISB ST 0601 does not define.
+var ErrUnknownTag = errors.New("unknown tag")
+
+// ErrDeprecatedTag reports a tag that MISB ST 0601 no longer uses.
+var ErrDeprecatedTag = errors.New("deprecated tag")
+
+// ErrOutOfBounds reports a decoded value outside the range defined for its tag.
+var ErrOutOfBounds = errors.New("value out of bounds")
+
+// ErrInvalidCoordinate reports a coordinate or grid precision that cannot be converted.
+var ErrInvalidCoordinate = errors.New("invalid coordinate")
+
+// ErrFrozenTelemetry reports dynamic tags that have not changed across the configured number of frames.
+var ErrFrozenTelemetry = errors.New("frozen telemetry")
+
+// ErrInconsistentTags reports tags whose values contradict each other within a packet.
+var ErrInconsistentTags = errors.New("inconsistent tags")
+
+// ErrNonMonotonicTimestamp reports a Precision Time Stamp earlier than the one of the previous packet.
+var ErrNonMonotonicTimestamp = errors.New("non-monotonic precision time stamp")
+
+// ErrTagOrder reports a tag that appears after a higher-numbered tag in the same packet.
+var ErrTagOrder = errors.New("tag out of order")
+
+// ErrLongitudeWraparound reports a longitude that jumped across the antimeridian between consecutive packets.
+var ErrLongitudeWraparound = errors.New("longitude wraparound")
+
+// ErrChecksumPosition reports a checksum (tag 1) that is not the last item of the local set.
+var ErrChecksumPosition = errors.New("checksum is not the last tag")
+
+// ErrBufferOverflow reports buffered bytes discarded because they exceeded MaxBufferSize.
+var ErrBufferOverflow = errors.New("buffer limit exceeded")
+
+// ErrFalseKey reports a key found inside the value of a packet whose start was missed.
+// The malformed packet it begins is skipped and decoding resumes at the next key.
+var ErrFalseKey = errors.New("key found inside a packet value")
+
+// ErrChecksumMismatch reports a packet whose checksum (tag 1) does not match its contents.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// ErrConcurrentUse reports a call to ProcessChunk made while another call on the same parser was still running.
+var ErrConcurrentUse = errors.New("parser used from multiple goroutines")
+
+// ChecksumError describes a checksum mismatch. It matches ErrChecksumMismatch with errors.Is.
+type ChecksumError struct {
+	Carried  uint16 // Checksum carried in tag 1
+	Computed uint16 // Checksum computed over the packet
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("%v: packet carries 0x%04X, computed 0x%04X", ErrChecksumMismatch, e.Carried, e.Computed)
+}
+
+// Unwrap returns ErrChecksumMismatch.
+func (e *ChecksumError) Unwrap() error {
+	return ErrChecksumMismatch
+}
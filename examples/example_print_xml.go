@@ -1,10 +1,8 @@
 package main
 
 import (
-	"bufio"
 	"encoding/xml"
 	"fmt"
-	"io"
 	"os"
 	"sort"
 
@@ -68,29 +66,10 @@ func main() {
 		printKLVTags(parsedTags)
 	})
 
-	// Reading data from stdin (or you can replace with a file reader)
-	reader := bufio.NewReader(os.Stdin)
-
-	for {
-		// Buffer size for reading chunks
-		chunk := make([]byte, 1024)
-
-		// Read a chunk of data
-		n, err := reader.Read(chunk)
-		if err != nil {
-			if err == io.EOF {
-				fmt.Println("End of input stream.")
-				break
-			}
-			fmt.Println("Error reading input:", err)
-			return
-		}
-
-		// Process the chunk with the KLV parser
-		err = parser.ProcessChunk(chunk[:n])
-		if err != nil {
-			fmt.Println("Error processing chunk:", err)
-			continue
-		}
+	// Read KLV data from stdin (or replace it with any other io.Reader) until it ends
+	if err := parser.ParseStream(os.Stdin); err != nil {
+		fmt.Println("Error reading input:", err)
+		return
 	}
+	fmt.Println("End of input stream.")
 }
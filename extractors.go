@@ -3,6 +3,7 @@ package klvparser
 import (
 	"encoding/binary"
 	"fmt"
+	"math"
 )
 
 // Extractors for 8-bit data types
@@ -55,11 +56,16 @@ func extractScaledUint16WithOffset(value []byte, scale, offset float64) *float64
 	return nil
 }
 
+// extractScaledInt16 returns nil for the reserved "out of range" indicator 0x8000.
 func extractScaledInt16(value []byte, scale float64) *float64 {
 	if len(value) < 2 {
 		return nil
 	}
-	val := float64(int16(binary.BigEndian.Uint16(value))) * scale
+	raw := int16(binary.BigEndian.Uint16(value))
+	if raw == math.MinInt16 {
+		return nil
+	}
+	val := float64(raw) * scale
 	return &val
 }
 
@@ -88,14 +94,39 @@ func extractScaledUint32(value []byte, scale float64) *float64 {
 	return nil
 }
 
+// extractScaledInt32 returns nil for the reserved "out of range" indicator 0x80000000.
 func extractScaledInt32(value []byte, scale float64) *float64 {
 	if len(value) < 4 {
 		return nil
 	}
-	val := float64(int32(binary.BigEndian.Uint32(value))) * scale
+	raw := int32(binary.BigEndian.Uint32(value))
+	if raw == math.MinInt32 {
+		return nil
+	}
+	val := float64(raw) * scale
 	return &val
 }
 
+// Latitudes and longitudes are signed 32-bit integers mapped linearly onto
+// ±90 and ±180 degrees, with the most negative value reserved:
+//
+//	latitude  = LS × 180 / (2^32 − 2)
+//	longitude = LS × 360 / (2^32 − 2)
+const (
+	latitudeScale  = 180.0 / (1<<32 - 2)
+	longitudeScale = 360.0 / (1<<32 - 2)
+)
+
+// extractLatitude decodes a 32-bit latitude in degrees.
+func extractLatitude(value []byte) *float64 {
+	return extractScaledInt32(value, latitudeScale)
+}
+
+// extractLongitude decodes a 32-bit longitude in degrees.
+func extractLongitude(value []byte) *float64 {
+	return extractScaledInt32(value, longitudeScale)
+}
+
 // Extractors for 64-bit data types
 func extractUint64(value []byte) *uint64 {
 	if len(value) >= 8 {
@@ -113,6 +144,16 @@ func extractInt64(value []byte) *int64 {
 	return nil
 }
 
+// extractUintN decodes a big-endian unsigned integer of up to 8 bytes, as used by
+// variable-length fields. Longer values keep their last 8 bytes.
+func extractUintN(value []byte) uint64 {
+	var result uint64
+	for _, b := range value {
+		result = result<<8 | uint64(b)
+	}
+	return result
+}
+
 // Extractor for hex representation
 func extractHex(value []byte) *string {
 	if len(value) > 0 {
@@ -122,6 +163,16 @@ func extractHex(value []byte) *string {
 	return nil
 }
 
+// imapbFraction maps the big-endian integer in data onto [0, 1].
+func imapbFraction(data []byte) float64 {
+	result := uint64(0)
+	for i := 0; i < len(data); i++ {
+		result = (result << 8) | uint64(data[i])
+	}
+	maxVal := math.Ldexp(1, 8*len(data)) - 1
+	return float64(result) / maxVal
+}
+
 // extractIMAPB decodes IMAPB-encoded values and applies scaling based on the data size.
 func extractIMAPB(val []byte) *float64 {
 	if len(val) == 0 {
@@ -133,15 +184,97 @@ func extractIMAPB(val []byte) *float64 {
 		return nil
 	}
 
-	data := val[1 : 1+length]
+	scaledValue := imapbFraction(val[1 : 1+length])
+	return &scaledValue
+}
 
-	result := uint64(0)
-	for i := 0; i < len(data); i++ {
-		result = (result << 8) | uint64(data[i])
+// extractIMAPBRange decodes an IMAPB-encoded value and maps it onto the range [min, max].
+func extractIMAPBRange(val []byte, min, max float64) *float64 {
+	fraction := extractIMAPB(val)
+	if fraction == nil {
+		return nil
 	}
+	scaledValue := min + *fraction*(max-min)
+	return &scaledValue
+}
 
-	maxVal := (1 << (8 * length)) - 1
-	scaledValue := float64(result) / float64(maxVal)
+// imapbSpecial decodes the reserved IMAPB codes of MISB ST 1201, which set the
+// most significant bit and leave every following bit but the three flag bits
+// clear: positive and negative infinity, and quiet and signaling NaN of either
+// sign, which all decode as NaN. ok is false for ordinary values.
+func imapbSpecial(val []byte) (float64, bool) {
+	if len(val) == 0 || int(val[0]) > len(val)-1 || val[0] == 0 {
+		return 0, false
+	}
+	data := val[1 : 1+val[0]]
+	for _, b := range data[1:] {
+		if b != 0 {
+			return 0, false
+		}
+	}
+	switch data[0] {
+	case 0xC8:
+		return math.Inf(1), true
+	case 0xE8:
+		return math.Inf(-1), true
+	case 0xD0, 0xD8, 0xF0, 0xF8:
+		return math.NaN(), true
+	}
+	return 0, false
+}
 
-	return &scaledValue
+// isErrorSentinel reports whether a signed value holds the reserved "out of range"
+// indicator, the most negative value of its width (0x80, 0x8000, 0x80000000, ...).
+func isErrorSentinel(value []byte) bool {
+	if len(value) == 0 || value[0] != 0x80 {
+		return false
+	}
+	for _, b := range value[1:] {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// extractBEROID decodes a BER-OID encoded integer: seven bits per byte, most
+// significant first, with the high bit set on every byte but the last. It returns
+// the value and the number of bytes consumed, or 0 bytes when the encoding is
+// truncated or does not fit in 64 bits.
+func extractBEROID(data []byte) (uint64, int) {
+	var value uint64
+	for i, b := range data {
+		if value > math.MaxUint64>>7 {
+			return 0, 0
+		}
+		value = value<<7 | uint64(b&0x7F)
+		if b&0x80 == 0 {
+			return value, i + 1
+		}
+	}
+	return 0, 0
+}
+
+// extractWavelengthList decodes an Active Wavelength List (tag 121): a BER-OID
+// record count followed by that many BER-OID wavelength identifiers. It returns
+// nil when the value does not hold exactly the announced number of entries.
+func extractWavelengthList(value []byte) []uint64 {
+	count, n := extractBEROID(value)
+	if n == 0 || count > uint64(len(value)-n) {
+		return nil
+	}
+	value = value[n:]
+	ids := make([]uint64, 0, count)
+	for uint64(len(ids)) < count {
+		id, n := extractBEROID(value)
+		if n == 0 {
+			return nil
+		}
+		ids = append(ids, id)
+		value = value[n:]
+	}
+	if len(value) != 0 {
+		return nil
+	}
+	return ids
 }
@@ -0,0 +1,72 @@
+package klvparser
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExtractBEROID(t *testing.T) {
+	tests := []struct {
+		data     []byte
+		value    uint64
+		consumed int
+	}{
+		{[]byte{0x05}, 5, 1},
+		{[]byte{0x7F, 0x01}, 127, 1},
+		{[]byte{0x81, 0x00}, 128, 2},
+		{[]byte{0x81, 0x02, 0x09}, 130, 2},
+		{[]byte{0xA0, 0x80, 0x00}, 1 << 19, 3},
+		{[]byte{0x81}, 0, 0},
+		{nil, 0, 0},
+		{[]byte{0x81, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0x7F}, 1<<64 - 1, 10},
+		{[]byte{0x82, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00}, 0, 0},
+	}
+	for _, tt := range tests {
+		value, consumed := extractBEROID(tt.data)
+		if value != tt.value || consumed != tt.consumed {
+			t.Errorf("extractBEROID(% x) = %d, %d; want %d, %d", tt.data, value, consumed, tt.value, tt.consumed)
+		}
+	}
+}
+
+func TestExtractWavelengthList(t *testing.T) {
+	// 130 entries need a two-byte count, and identifiers from 128 two bytes each.
+	value := []byte{0x81, 0x02}
+	for id := 1; id <= 130; id++ {
+		if id >= 128 {
+			value = append(value, 0x81, byte(id-128))
+		} else {
+			value = append(value, byte(id))
+		}
+	}
+	ids := extractWavelengthList(value)
+	if len(ids) != 130 {
+		t.Fatalf("decoded %d identifiers, want 130", len(ids))
+	}
+	for i, id := range ids {
+		if id != uint64(i+1) {
+			t.Fatalf("identifier %d = %d, want %d", i, id, i+1)
+		}
+	}
+	if ids := extractWavelengthList(value[:len(value)-1]); ids != nil {
+		t.Errorf("truncated list decoded to %d identifiers", len(ids))
+	}
+	if ids := extractWavelengthList(append(value, 7)); ids != nil {
+		t.Errorf("list with a trailing byte decoded to %d identifiers", len(ids))
+	}
+}
+
+func TestCoordinateScaleSampleVector(t *testing.T) {
+	// Values of the MISB ST 0601 example packet.
+	tags := decodeOne(t, pkt(true,
+		item(13, 0x55, 0x95, 0xB6, 0x6D),
+		item(14, 0x5B, 0x53, 0x60, 0xC4),
+		item(23, 0xF1, 0x01, 0xA2, 0x29),
+		item(24, 0x14, 0xBC, 0x08, 0x2B),
+	))
+	for tag, want := range map[int]float64{13: 60.17682297, 14: 128.42675904, 23: -10.54238863, 24: 29.15789012} {
+		if got, _ := tags[tag].Value.(float64); math.Abs(got-want) > 1e-8 {
+			t.Errorf("tag %d = %v, want %v", tag, tags[tag].Value, want)
+		}
+	}
+}
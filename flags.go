@@ -0,0 +1,29 @@
+package klvparser
+
+// GenericFlags holds the decoded bits of Generic Flag Data 01 (tag 47).
+type GenericFlags struct {
+	Raw                uint8 // The flag byte as transmitted
+	LaserRange         bool  // Bit 0: laser range finder on
+	AutoTrack          bool  // Bit 1: auto-track on
+	IRPolarityBlackHot bool  // Bit 2: IR polarity black hot (white hot when false)
+	IcingDetected      bool  // Bit 3: icing detected
+	SlantRangeMeasured bool  // Bit 4: slant range measured (calculated when false)
+	ImageInvalid       bool  // Bit 5: image invalid
+}
+
+// extractGenericFlags decodes the Generic Flag Data 01 byte.
+func extractGenericFlags(value []byte) *GenericFlags {
+	raw := extractUint8(value)
+	if raw == nil {
+		return nil
+	}
+	return &GenericFlags{
+		Raw:                *raw,
+		LaserRange:         *raw&0x01 != 0,
+		AutoTrack:          *raw&0x02 != 0,
+		IRPolarityBlackHot: *raw&0x04 != 0,
+		IcingDetected:      *raw&0x08 != 0,
+		SlantRangeMeasured: *raw&0x10 != 0,
+		ImageInvalid:       *raw&0x20 != 0,
+	}
+}
@@ -0,0 +1,77 @@
+package klvparser
+
+import "math"
+
+// FootprintDimensions estimates the width and height in meters of the ground area
+// covered by the image. The preferred estimate spans the horizontal and vertical
+// fields of view (tags 16 and 17) at the distance to the frame center, which is
+// taken from the Slant Range (tag 21), the sensor and frame center positions, or
+// the sensor altitude above the frame center, in that order; it ignores the
+// stretching of oblique views. Without a field of view or distance, the
+// dimensions are measured between the image corners (tags 82-89, or the offsets
+// in tags 26-33 applied to the frame center).
+func FootprintDimensions(tags map[int]*KLVTag) (width, height float64, ok bool) {
+	if fov, ok := tagFloats(tags, 16, 17); ok {
+		if distance, ok := footprintDistance(tags); ok {
+			width = 2 * distance * math.Tan(fov[0]*math.Pi/360)
+			height = 2 * distance * math.Tan(fov[1]*math.Pi/360)
+			return width, height, true
+		}
+	}
+	corners, ok := imageCorners(tags)
+	if !ok {
+		return 0, 0, false
+	}
+	// Corners run clockwise from the upper left, so 1-2 and 3-4 span the width.
+	width = (groundDistance(corners[0], corners[1]) + groundDistance(corners[2], corners[3])) / 2
+	height = (groundDistance(corners[1], corners[2]) + groundDistance(corners[3], corners[0])) / 2
+	return width, height, true
+}
+
+// footprintDistance returns the distance in meters from the sensor to the frame center.
+func footprintDistance(tags map[int]*KLVTag) (float64, bool) {
+	if distance, ok := tagFloat(tags, 21); ok {
+		return distance, true
+	}
+	if distance, ok := ComputedSlantRange(tags); ok {
+		return distance, true
+	}
+	altitude, ok := tagFloat(tags, 15)
+	if !ok {
+		return 0, false
+	}
+	if elevation, ok := tagFloat(tags, 25); ok {
+		altitude -= elevation
+	}
+	return altitude, altitude > 0
+}
+
+// imageCorners returns the latitude and longitude of the four image corners.
+func imageCorners(tags map[int]*KLVTag) ([4][2]float64, bool) {
+	var corners [4][2]float64
+	if full, ok := tagFloats(tags, 82, 83, 84, 85, 86, 87, 88, 89); ok {
+		for i := range corners {
+			corners[i] = [2]float64{full[2*i], full[2*i+1]}
+		}
+		return corners, true
+	}
+	center, ok := tagFloats(tags, 23, 24)
+	if !ok {
+		return corners, false
+	}
+	offsets, ok := tagFloats(tags, 26, 27, 28, 29, 30, 31, 32, 33)
+	if !ok {
+		return corners, false
+	}
+	for i := range corners {
+		corners[i] = [2]float64{center[0] + offsets[2*i], center[1] + offsets[2*i+1]}
+	}
+	return corners, true
+}
+
+// groundDistance returns the straight-line distance in meters between two points on the ellipsoid.
+func groundDistance(a, b [2]float64) float64 {
+	x1, y1, z1 := geodeticToECEF(a[0], a[1], 0)
+	x2, y2, z2 := geodeticToECEF(b[0], b[1], 0)
+	return math.Sqrt((x2-x1)*(x2-x1) + (y2-y1)*(y2-y1) + (z2-z1)*(z2-z1))
+}
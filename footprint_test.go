@@ -0,0 +1,35 @@
+package klvparser
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFootprintDimensions(t *testing.T) {
+	fov := map[int]*KLVTag{16: {Value: 90.0}, 17: {Value: 60.0}}
+	withSlantRange := map[int]*KLVTag{16: fov[16], 17: fov[17], 21: {Value: 1000.0}}
+	withAltitude := map[int]*KLVTag{16: fov[16], 17: fov[17], 15: {Value: 1500.0}, 25: {Value: 500.0}}
+	for name, tags := range map[string]map[int]*KLVTag{"slant range": withSlantRange, "altitude": withAltitude} {
+		// At 1000 m, 90° spans 2000 m and 60° spans 2000 tan 30°.
+		width, height, ok := FootprintDimensions(tags)
+		if !ok || math.Abs(width-2000) > 1e-6 || math.Abs(height-2000*math.Tan(math.Pi/6)) > 1e-6 {
+			t.Errorf("%s: footprint = %v x %v, %v, want 2000 x 1154.7", name, width, height, ok)
+		}
+	}
+
+	// Corners 0.01° apart around the origin: about 1113 m of longitude and 1106 m of latitude.
+	corners := map[int]*KLVTag{
+		82: {Value: 0.005}, 83: {Value: -0.005},
+		84: {Value: 0.005}, 85: {Value: 0.005},
+		86: {Value: -0.005}, 87: {Value: 0.005},
+		88: {Value: -0.005}, 89: {Value: -0.005},
+	}
+	width, height, ok := FootprintDimensions(corners)
+	if !ok || math.Abs(width-1113.2) > 0.5 || math.Abs(height-1105.7) > 0.5 {
+		t.Errorf("corner footprint = %v x %v, %v, want 1113.2 x 1105.7", width, height, ok)
+	}
+
+	if _, _, ok := FootprintDimensions(fov); ok {
+		t.Error("FootprintDimensions without a distance or corners is ok")
+	}
+}
@@ -0,0 +1,49 @@
+package klvparser
+
+import "math/big"
+
+// FrameRate returns the exact rate carried by the Sensor Frame Rate Pack (tag 127)
+// in frames per second, such as 24000/1001. The tag's Value holds the same rate
+// as a float64. ok is false for other tags and for packs that could not be decoded.
+func (t *KLVTag) FrameRate() (*big.Rat, bool) {
+	if t == nil || t.rate == nil {
+		return nil, false
+	}
+	return new(big.Rat).Set(t.rate), true
+}
+
+// processFrameRate decodes a Sensor Frame Rate Pack: a BER-OID numerator followed
+// by an optional BER-OID denominator, which defaults to 1.
+func (p *KLVParser) processFrameRate(tag int, value []byte) {
+	meta := p.tagMeta[tag]
+	if meta == nil {
+		return
+	}
+	meta.rate = nil
+	rate := extractFrameRate(value)
+	if rate == nil {
+		meta.Value = extractHex(value)
+		return
+	}
+	meta.rate = rate
+	meta.Value, _ = rate.Float64()
+}
+
+// extractFrameRate decodes the numerator and denominator of a Sensor Frame Rate
+// Pack, or returns nil when the value does not match this layout or the
+// denominator is zero.
+func extractFrameRate(value []byte) *big.Rat {
+	numerator, n := extractBEROID(value)
+	if n == 0 {
+		return nil
+	}
+	value = value[n:]
+	denominator := uint64(1)
+	if len(value) > 0 {
+		denominator, n = extractBEROID(value)
+		if n != len(value) || denominator == 0 {
+			return nil
+		}
+	}
+	return new(big.Rat).SetFrac(new(big.Int).SetUint64(numerator), new(big.Int).SetUint64(denominator))
+}
@@ -0,0 +1,27 @@
+package klvparser
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestFrameRate(t *testing.T) {
+	// 24000 and 1001 as BER-OID values.
+	tag := decodeOne(t, pkt(true, item(127, 0x81, 0xBB, 0x40, 0x87, 0x69)))[127]
+	rate, ok := tag.FrameRate()
+	if !ok || rate.Cmp(big.NewRat(24000, 1001)) != 0 {
+		t.Errorf("FrameRate = %v, %v, want 24000/1001", rate, ok)
+	}
+	if value, _ := tag.Value.(float64); math.Abs(value-23.976) > 0.001 {
+		t.Errorf("value = %v, want 23.976", tag.Value)
+	}
+
+	rate, ok = decodeOne(t, pkt(true, item(127, 60)))[127].FrameRate()
+	if !ok || rate.Cmp(big.NewRat(60, 1)) != 0 {
+		t.Errorf("FrameRate without a denominator = %v, %v, want 60", rate, ok)
+	}
+	if _, ok := decodeOne(t, pkt(true, item(127, 60, 0)))[127].FrameRate(); ok {
+		t.Error("FrameRate with a zero denominator is ok")
+	}
+}
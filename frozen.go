@@ -0,0 +1,62 @@
+package klvparser
+
+import "fmt"
+
+// dynamicTags are the tags expected to change between frames of a moving platform:
+// Platform Heading Angle, Sensor Latitude, and Sensor Longitude.
+var dynamicTags = []int{5, 13, 14}
+
+// frozenState tracks how many consecutive frames carried identical dynamic values.
+type frozenState struct {
+	threshold int
+	count     int
+	last      []interface{}
+}
+
+// SetFrozenThreshold enables detection of frozen telemetry. When the dynamic tags
+// (heading, latitude, longitude) carry identical values for the given number of
+// consecutive frames, ErrFrozenTelemetry is reported through OnError.
+// A threshold of 0 disables the check.
+func (p *KLVParser) SetFrozenThreshold(frames int) {
+	p.frozen = frozenState{threshold: frames}
+}
+
+// checkFrozen compares the dynamic tags of a frame against the previous frame.
+func (p *KLVParser) checkFrozen(parsedTags map[int]*KLVTag) {
+	if p.frozen.threshold <= 0 {
+		return
+	}
+
+	current := make([]interface{}, len(dynamicTags))
+	present := false
+	for i, tag := range dynamicTags {
+		if data, ok := parsedTags[tag]; ok && data.Value != nil {
+			current[i] = data.Value
+			present = true
+		}
+	}
+	if !present {
+		return
+	}
+
+	if p.frozen.last != nil && sameValues(current, p.frozen.last) {
+		p.frozen.count++
+	} else {
+		p.frozen.count = 1
+	}
+	p.frozen.last = current
+
+	if p.frozen.count == p.frozen.threshold {
+		p.reportError(0, fmt.Errorf("%w: tags %v unchanged for %d frames", ErrFrozenTelemetry, dynamicTags, p.frozen.count))
+	}
+}
+
+// sameValues reports whether two value slices are element-wise equal.
+func sameValues(a, b []interface{}) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
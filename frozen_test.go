@@ -0,0 +1,35 @@
+package klvparser
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFrozenTelemetry(t *testing.T) {
+	frame := func(heading uint16) []byte {
+		return pkt(true, item(5, u16(heading)...), item(13, u32(0x10000000)...), item(14, u32(0x20000000)...))
+	}
+	tests := []struct {
+		name   string
+		stream [][]byte
+		frozen bool
+	}{
+		{"identical frames", [][]byte{frame(1), frame(1), frame(1)}, true},
+		{"heading changes", [][]byte{frame(1), frame(1), frame(2)}, false},
+	}
+	for _, tt := range tests {
+		var errs []error
+		p := NewKLVParser(func(map[int]*KLVTag) {})
+		p.OnError = func(tag int, err error) { errs = append(errs, err) }
+		p.SetFrozenThreshold(3)
+		for _, packet := range tt.stream {
+			if err := p.ProcessChunk(packet); err != nil {
+				t.Fatal(err)
+			}
+		}
+		frozen := len(errs) == 1 && errors.Is(errs[0], ErrFrozenTelemetry)
+		if frozen != tt.frozen || len(errs) > 1 {
+			t.Errorf("%s: errors %v, want frozen %v", tt.name, errs, tt.frozen)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package klvparser
+
+import "math"
+
+// PositionSource identifies which tags a platform position was taken from.
+type PositionSource int
+
+const (
+	// PositionNone means no position was available.
+	PositionNone PositionSource = iota
+	// PositionSensor means the position came from Sensor Latitude, Longitude, and True Altitude (tags 13, 14, 15).
+	PositionSensor
+	// PositionAlternatePlatform means the position came from the Alternate Platform tags (67, 68, 69).
+	PositionAlternatePlatform
+)
+
+// PlatformPosition returns the platform's geodetic position, preferring the sensor
+// position and falling back to the alternate platform fields. The altitude is NaN
+// when the selected source carries no altitude.
+func PlatformPosition(tags map[int]*KLVTag) (lat, lon, alt float64, ok bool) {
+	lat, lon, alt, source := PlatformPositionWithSource(tags)
+	return lat, lon, alt, source != PositionNone
+}
+
+// PlatformPositionWithSource is like PlatformPosition but also reports which tags the position came from.
+func PlatformPositionWithSource(tags map[int]*KLVTag) (lat, lon, alt float64, source PositionSource) {
+	candidates := []struct {
+		source                 PositionSource
+		latTag, lonTag, altTag int
+	}{
+		{PositionSensor, 13, 14, 15},
+		{PositionAlternatePlatform, 67, 68, 69},
+	}
+	for _, c := range candidates {
+		lat, latOK := tagFloat(tags, c.latTag)
+		lon, lonOK := tagFloat(tags, c.lonTag)
+		if !latOK || !lonOK {
+			continue
+		}
+		alt, altOK := tagFloat(tags, c.altTag)
+		if !altOK {
+			alt = math.NaN()
+		}
+		return lat, lon, alt, c.source
+	}
+	return 0, 0, math.NaN(), PositionNone
+}
+
+// WGS 84 ellipsoid parameters.
+const (
+	wgs84SemiMajorAxis  = 6378137.0
+	wgs84Flattening     = 1 / 298.257223563
+	wgs84EccentricitySq = wgs84Flattening * (2 - wgs84Flattening)
+)
+
+// geodeticToECEF converts a WGS 84 position in degrees and meters to Earth-centered,
+// Earth-fixed coordinates in meters.
+func geodeticToECEF(lat, lon, height float64) (x, y, z float64) {
+	latRad := lat * math.Pi / 180
+	lonRad := lon * math.Pi / 180
+	sinLat := math.Sin(latRad)
+	n := wgs84SemiMajorAxis / math.Sqrt(1-wgs84EccentricitySq*sinLat*sinLat)
+	x = (n + height) * math.Cos(latRad) * math.Cos(lonRad)
+	y = (n + height) * math.Cos(latRad) * math.Sin(lonRad)
+	z = (n*(1-wgs84EccentricitySq) + height) * sinLat
+	return x, y, z
+}
+
+// ComputedSlantRange returns the straight-line distance in meters between the sensor
+// (tags 13, 14, 15) and the frame center (tags 23, 24, 25), for cross-checking the
+// reported Slant Range (tag 21). Both altitudes are treated as heights above the
+// ellipsoid, which is accurate to the local geoid separation.
+func ComputedSlantRange(tags map[int]*KLVTag) (float64, bool) {
+	sensor, ok := tagFloats(tags, 13, 14, 15)
+	if !ok {
+		return 0, false
+	}
+	center, ok := tagFloats(tags, 23, 24, 25)
+	if !ok {
+		return 0, false
+	}
+	x1, y1, z1 := geodeticToECEF(sensor[0], sensor[1], sensor[2])
+	x2, y2, z2 := geodeticToECEF(center[0], center[1], center[2])
+	return math.Sqrt((x2-x1)*(x2-x1) + (y2-y1)*(y2-y1) + (z2-z1)*(z2-z1)), true
+}
+
+// tagFloats returns the numeric values of several tags, if all are present.
+func tagFloats(tags map[int]*KLVTag, ids ...int) ([]float64, bool) {
+	values := make([]float64, len(ids))
+	for i, id := range ids {
+		value, ok := tagFloat(tags, id)
+		if !ok {
+			return nil, false
+		}
+		values[i] = value
+	}
+	return values, true
+}
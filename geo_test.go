@@ -0,0 +1,53 @@
+package klvparser
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPlatformPositionFallback(t *testing.T) {
+	alternate := map[int]*KLVTag{67: {Value: 51.5}, 68: {Value: -0.12}, 69: {Value: 300.0}}
+	lat, lon, alt, source := PlatformPositionWithSource(alternate)
+	if source != PositionAlternatePlatform || lat != 51.5 || lon != -0.12 || alt != 300 {
+		t.Errorf("alternate position = %v, %v, %v from %v", lat, lon, alt, source)
+	}
+
+	// The sensor position is preferred, even without an altitude.
+	both := map[int]*KLVTag{13: {Value: 10.0}, 14: {Value: 20.0}, 67: {Value: 51.5}, 68: {Value: -0.12}}
+	lat, lon, alt, source = PlatformPositionWithSource(both)
+	if source != PositionSensor || lat != 10 || lon != 20 || !math.IsNaN(alt) {
+		t.Errorf("sensor position = %v, %v, %v from %v", lat, lon, alt, source)
+	}
+
+	if _, _, _, ok := PlatformPosition(map[int]*KLVTag{67: {Value: 51.5}}); ok {
+		t.Error("PlatformPosition without a longitude is ok")
+	}
+}
+
+func TestComputedSlantRange(t *testing.T) {
+	position := func(sensorLat, sensorLon, sensorAlt, centerLat, centerLon, centerAlt float64) map[int]*KLVTag {
+		return map[int]*KLVTag{
+			13: {Value: sensorLat}, 14: {Value: sensorLon}, 15: {Value: sensorAlt},
+			23: {Value: centerLat}, 24: {Value: centerLon}, 25: {Value: centerAlt},
+		}
+	}
+	tests := []struct {
+		name string
+		tags map[int]*KLVTag
+		want float64
+	}{
+		{"straight down", position(45, 10, 1000, 45, 10, 0), 1000},
+		// A chord of 0.01° of longitude along the equator: 2a sin(0.005°).
+		{"along the equator", position(0, 0, 0, 0, 0.01, 0), 2 * wgs84SemiMajorAxis * math.Sin(0.005*math.Pi/180)},
+	}
+	for _, tt := range tests {
+		if got, ok := ComputedSlantRange(tt.tags); !ok || math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("%s: slant range = %v, %v, want %v", tt.name, got, ok, tt.want)
+		}
+	}
+	tags := position(45, 10, 1000, 45, 10, 0)
+	delete(tags, 25)
+	if _, ok := ComputedSlantRange(tags); ok {
+		t.Error("ComputedSlantRange without the frame center elevation is ok")
+	}
+}
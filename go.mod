@@ -0,0 +1,3 @@
+module github.com/StefanGrimminck/klvparser
+
+go 1.23
@@ -0,0 +1,83 @@
+package klvparser
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"hash/fnv"
+	"math"
+	"sort"
+)
+
+// FrameHash returns a 64-bit FNV-1a hash of a decoded frame's tag numbers and
+// values, for deduplication and caching. Tags are hashed in ascending order, so
+// frames with the same contents hash equal regardless of map iteration order.
+// Names, units, and bounds are not part of the hash.
+func FrameHash(tags map[int]*KLVTag) uint64 {
+	h := fnv.New64a()
+	hashTags(h, tags)
+	return h.Sum64()
+}
+
+// hashTags writes the tags of a frame or nested set to h in ascending tag order.
+func hashTags(h hash.Hash64, tags map[int]*KLVTag) {
+	ids := make([]int, 0, len(tags))
+	for id := range tags {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	var buf [8]byte
+	for _, id := range ids {
+		binary.BigEndian.PutUint64(buf[:], uint64(id))
+		h.Write(buf[:])
+		data := tags[id]
+		if data == nil {
+			h.Write([]byte{0})
+			continue
+		}
+		hashValue(h, data.Value)
+		if len(data.Children) > 0 {
+			h.Write([]byte{'{'})
+			hashTags(h, data.Children)
+			h.Write([]byte{'}'})
+		}
+	}
+}
+
+// hashValue writes a canonical encoding of a tag value to h: a type marker
+// followed by the value's bytes.
+func hashValue(h hash.Hash64, value interface{}) {
+	var buf [8]byte
+	switch v := value.(type) {
+	case nil:
+		h.Write([]byte{0})
+	case float64:
+		if math.IsNaN(v) {
+			// NaN has many bit patterns; all of them mean the same missing value.
+			v = math.NaN()
+		}
+		h.Write([]byte{'f'})
+		binary.BigEndian.PutUint64(buf[:], math.Float64bits(v))
+		h.Write(buf[:])
+	case string:
+		hashString(h, v)
+	case *string:
+		if v == nil {
+			h.Write([]byte{0})
+			return
+		}
+		hashString(h, *v)
+	default:
+		hashString(h, fmt.Sprintf("%T:%v", v, v))
+	}
+}
+
+// hashString writes a length-prefixed string to h, so adjacent values cannot run together.
+func hashString(h hash.Hash64, s string) {
+	var buf [8]byte
+	h.Write([]byte{'s'})
+	binary.BigEndian.PutUint64(buf[:], uint64(len(s)))
+	h.Write(buf[:])
+	h.Write([]byte(s))
+}
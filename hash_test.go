@@ -0,0 +1,36 @@
+package klvparser
+
+import "testing"
+
+func TestFrameHash(t *testing.T) {
+	packet := pkt(true, item(2, u64(5)...), item(3, []byte("MISSION")...), item(5, u16(0x1234)...),
+		item(13, u32(0x10000000)...), item(48, item(1, 3)...))
+	first, second := decodeOne(t, packet), decodeOne(t, packet)
+	// The checksum changes with any other value, so leave it out of the comparison.
+	delete(first, 1)
+	delete(second, 1)
+	if FrameHash(first) != FrameHash(second) {
+		t.Fatal("identical frames hash differently")
+	}
+	// Hashing in a different insertion order must not matter.
+	reordered := make(map[int]*KLVTag)
+	for _, tag := range []int{48, 13, 5, 3, 2} {
+		reordered[tag] = first[tag]
+	}
+	if FrameHash(reordered) != FrameHash(first) {
+		t.Error("frame hash depends on map order")
+	}
+
+	changed := decodeOne(t, pkt(true, item(2, u64(5)...), item(3, []byte("MISSION")...), item(5, u16(0x1235)...),
+		item(13, u32(0x10000000)...), item(48, item(1, 3)...)))
+	delete(changed, 1)
+	if FrameHash(changed) == FrameHash(first) {
+		t.Error("a changed heading does not change the hash")
+	}
+	changed = decodeOne(t, pkt(true, item(2, u64(5)...), item(3, []byte("MISSION")...), item(5, u16(0x1234)...),
+		item(13, u32(0x10000000)...), item(48, item(1, 4)...)))
+	delete(changed, 1)
+	if FrameHash(changed) == FrameHash(first) {
+		t.Error("a changed security classification does not change the hash")
+	}
+}
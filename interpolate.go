@@ -0,0 +1,34 @@
+package klvparser
+
+import "math"
+
+// InterpolateTelemetry estimates the telemetry at fraction frac of the way from a
+// to b, with 0 giving a and 1 giving b. Fields are interpolated linearly, except
+// that the heading and longitudes take the shorter way around the circle, so
+// 350° to 10° passes through 0°. A field that is NaN in a or b is NaN in the result.
+func InterpolateTelemetry(a, b Telemetry, frac float64) Telemetry {
+	var t Telemetry
+	t.Timestamp = uint64(math.Round(float64(a.Timestamp) + frac*(float64(b.Timestamp)-float64(a.Timestamp))))
+	from, to, fields := a.fields(), b.fields(), t.fields()
+	for i, field := range fields {
+		x, y := *from[i], *to[i]
+		switch telemetryTags[i] {
+		case 5:
+			// Platform Heading: 0 to 360 degrees
+			*field = math.Mod(interpolateAngle(x, y, frac)+360, 360)
+		case 14, 24:
+			// Longitudes: -180 to 180 degrees
+			*field = math.Mod(interpolateAngle(x, y, frac)+540, 360) - 180
+		default:
+			*field = x + frac*(y-x)
+		}
+	}
+	return t
+}
+
+// interpolateAngle interpolates between two angles in degrees along the shorter arc.
+// The result is not normalized.
+func interpolateAngle(from, to, frac float64) float64 {
+	delta := math.Mod(math.Mod(to-from, 360)+540, 360) - 180
+	return from + frac*delta
+}
@@ -0,0 +1,27 @@
+package klvparser
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInterpolateTelemetry(t *testing.T) {
+	a := Telemetry{Timestamp: 100, PlatformHeading: 350, SensorLatitude: 10, SensorLongitude: 179}
+	b := Telemetry{Timestamp: 200, PlatformHeading: 10, SensorLatitude: 20, SensorLongitude: -177, PlatformPitch: math.NaN()}
+	got := InterpolateTelemetry(a, b, 0.5)
+	if got.Timestamp != 150 {
+		t.Errorf("timestamp = %d, want 150", got.Timestamp)
+	}
+	if got.PlatformHeading != 0 {
+		t.Errorf("heading halfway from 350° to 10° = %v, want 0", got.PlatformHeading)
+	}
+	if got.SensorLatitude != 15 {
+		t.Errorf("latitude = %v, want 15", got.SensorLatitude)
+	}
+	if math.Abs(got.SensorLongitude+179) > 1e-9 {
+		t.Errorf("longitude halfway from 179° to -177° = %v, want -179", got.SensorLongitude)
+	}
+	if !math.IsNaN(got.PlatformPitch) {
+		t.Errorf("pitch = %v, want NaN", got.PlatformPitch)
+	}
+}
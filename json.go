@@ -0,0 +1,45 @@
+package klvparser
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// jsonTag is the JSON representation of a single decoded tag.
+type jsonTag struct {
+	Name        string      `json:"name"`
+	Value       interface{} `json:"value,omitempty"`
+	Unit        string      `json:"unit,omitempty"`
+	Uncertainty *float64    `json:"uncertainty,omitempty"`
+}
+
+// newJSONTag converts a tag to its JSON representation, dropping NaN and
+// infinite values, which JSON cannot represent.
+func newJSONTag(t *KLVTag) jsonTag {
+	value := t.Value
+	if f, ok := value.(float64); ok && (math.IsNaN(f) || math.IsInf(f, 0)) {
+		value = nil
+	}
+	return jsonTag{Name: t.Name, Value: value, Unit: t.Unit, Uncertainty: t.Uncertainty}
+}
+
+// MarshalJSON encodes the tag as an object with its name, value, unit and
+// uncertainty.
+// Numeric values stay numbers; absent and NaN values are omitted.
+func (t *KLVTag) MarshalJSON() ([]byte, error) {
+	return json.Marshal(newJSONTag(t))
+}
+
+// MarshalTags encodes a tag map as a JSON object keyed by tag ID.
+// Absent and NaN values are omitted.
+func MarshalTags(tags map[int]*KLVTag) ([]byte, error) {
+	object := make(map[string]jsonTag, len(tags))
+	for tag, data := range tags {
+		if data == nil {
+			continue
+		}
+		object[strconv.Itoa(tag)] = newJSONTag(data)
+	}
+	return json.Marshal(object)
+}
@@ -0,0 +1,79 @@
+// Package klvarrow collects decoded MISB ST 0601 frames into Apache Arrow record
+// batches for columnar processing. It is a separate module so the core
+// parser stays free of the Arrow dependency.
+package klvarrow
+
+import (
+	"strconv"
+
+	"github.com/StefanGrimminck/klvparser"
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// Builder appends frames as rows of a record batch with one float64 column per tag.
+// Columns are named after the tag codes and carry the tag number in their metadata.
+// A tag that is missing from a frame, or whose value is not numeric, is stored as null.
+type Builder struct {
+	tags    []int
+	schema  *arrow.Schema
+	records *array.RecordBuilder
+}
+
+// NewBuilder creates a Builder whose columns are the given tags, in order.
+// A nil allocator selects the Go heap allocator.
+func NewBuilder(tags []int, mem memory.Allocator) *Builder {
+	if mem == nil {
+		mem = memory.NewGoAllocator()
+	}
+	fields := make([]arrow.Field, len(tags))
+	for i, tag := range tags {
+		fields[i] = arrow.Field{
+			Name:     klvparser.TagCode(tag),
+			Type:     arrow.PrimitiveTypes.Float64,
+			Nullable: true,
+			Metadata: arrow.NewMetadata([]string{"tag"}, []string{strconv.Itoa(tag)}),
+		}
+	}
+	schema := arrow.NewSchema(fields, nil)
+	return &Builder{
+		tags:    append([]int(nil), tags...),
+		schema:  schema,
+		records: array.NewRecordBuilder(mem, schema),
+	}
+}
+
+// Schema returns the schema of the record batches produced by the Builder.
+func (b *Builder) Schema() *arrow.Schema {
+	return b.schema
+}
+
+// Append adds a decoded frame as a new row.
+func (b *Builder) Append(tags map[int]*klvparser.KLVTag) {
+	for i, tag := range b.tags {
+		column := b.records.Field(i).(*array.Float64Builder)
+		data, ok := tags[tag]
+		if !ok || data == nil {
+			column.AppendNull()
+			continue
+		}
+		value, ok := data.Value.(float64)
+		if !ok {
+			column.AppendNull()
+			continue
+		}
+		column.Append(value)
+	}
+}
+
+// NewRecord returns the rows appended so far as a record batch and resets the
+// Builder for the next batch. The caller must Release the record.
+func (b *Builder) NewRecord() array.Record {
+	return b.records.NewRecord()
+}
+
+// Release frees the memory held by the Builder.
+func (b *Builder) Release() {
+	b.records.Release()
+}
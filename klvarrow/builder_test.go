@@ -0,0 +1,56 @@
+package klvarrow
+
+import (
+	"testing"
+
+	"github.com/StefanGrimminck/klvparser"
+	"github.com/apache/arrow/go/arrow/array"
+)
+
+func TestBuilder(t *testing.T) {
+	b := NewBuilder([]int{5, 13}, nil)
+	defer b.Release()
+
+	schema := b.Schema()
+	for i, want := range []struct{ name, tag string }{{"PLAT_HDG", "5"}, {"SENS_LAT", "13"}} {
+		field := schema.Field(i)
+		if field.Name != want.name || !field.Nullable {
+			t.Errorf("field %d = %s, nullable %v, want nullable %s", i, field.Name, field.Nullable, want.name)
+		}
+		if index := field.Metadata.FindKey("tag"); index < 0 || field.Metadata.Values()[index] != want.tag {
+			t.Errorf("field %s metadata = %v, want tag %s", field.Name, field.Metadata, want.tag)
+		}
+	}
+
+	b.Append(map[int]*klvparser.KLVTag{5: {Value: 90.0}, 13: {Value: 51.5}})
+	b.Append(map[int]*klvparser.KLVTag{5: {Value: 180.0}})
+	b.Append(map[int]*klvparser.KLVTag{5: nil, 13: {Value: "not a number"}})
+	record := b.NewRecord()
+	defer record.Release()
+
+	if record.NumRows() != 3 || record.NumCols() != 2 {
+		t.Fatalf("record has %d rows and %d columns, want 3 and 2", record.NumRows(), record.NumCols())
+	}
+	want := [][]interface{}{{90.0, 180.0, nil}, {51.5, nil, nil}}
+	for col, values := range want {
+		column := record.Column(col).(*array.Float64)
+		for row, value := range values {
+			if value == nil {
+				if !column.IsNull(row) {
+					t.Errorf("column %d row %d = %v, want null", col, row, column.Value(row))
+				}
+				continue
+			}
+			if column.IsNull(row) || column.Value(row) != value {
+				t.Errorf("column %d row %d = %v, want %v", col, row, column.Value(row), value)
+			}
+		}
+	}
+
+	// The Builder starts over after NewRecord.
+	next := b.NewRecord()
+	defer next.Release()
+	if next.NumRows() != 0 {
+		t.Errorf("next record has %d rows, want 0", next.NumRows())
+	}
+}
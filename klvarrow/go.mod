@@ -0,0 +1,12 @@
+module github.com/StefanGrimminck/klvparser/klvarrow
+
+go 1.23
+
+require (
+	github.com/StefanGrimminck/klvparser v0.0.0
+	github.com/apache/arrow/go/arrow v0.0.0-20211112161151-bc219186db40
+)
+
+require golang.org/x/xerrors v0.0.0-20240903120638-7835f813f4da // indirect
+
+replace github.com/StefanGrimminck/klvparser => ../
@@ -0,0 +1,68 @@
+// Package klvgeojson exports decoded MISB ST 0601 frames as GeoJSON.
+// It is a separate module so the core parser stays free of dependencies.
+package klvgeojson
+
+import (
+	"time"
+
+	"github.com/StefanGrimminck/klvparser"
+	geojson "github.com/paulmach/go.geojson"
+)
+
+// cornerTags lists the latitude and longitude tags of the image corners
+// (Corner Latitude/Longitude Point 1-4, full), clockwise from the upper left.
+var cornerTags = [4][2]int{{82, 83}, {84, 85}, {86, 87}, {88, 89}}
+
+// FrameFootprint returns the ground footprint of the frame as a GeoJSON Polygon
+// feature built from the four image corners (tags 82-89). The frame center
+// (tags 23 and 24) and the Precision Time Stamp (tag 2) are added as the
+// "center" and "timestamp" properties when present. ok is false when any
+// corner is missing.
+func FrameFootprint(tags map[int]*klvparser.KLVTag) (feature *geojson.Feature, ok bool) {
+	ring := make([][]float64, 0, len(cornerTags)+1)
+	for _, corner := range cornerTags {
+		position, ok := position(tags, corner[0], corner[1])
+		if !ok {
+			return nil, false
+		}
+		ring = append(ring, position)
+	}
+	// GeoJSON rings end where they start.
+	ring = append(ring, ring[0])
+
+	feature = geojson.NewPolygonFeature([][][]float64{ring})
+	if center, ok := position(tags, 23, 24); ok {
+		feature.SetProperty("center", center)
+	}
+	if stamp, ok := tags[2].PrecisionTime(); ok {
+		feature.SetProperty("timestamp", stamp.Format(time.RFC3339Nano))
+	}
+	return feature, true
+}
+
+// position returns the GeoJSON position, longitude first, held by a latitude
+// and a longitude tag.
+func position(tags map[int]*klvparser.KLVTag, latTag, lonTag int) ([]float64, bool) {
+	lat, ok := tagFloat(tags, latTag)
+	if !ok {
+		return nil, false
+	}
+	lon, ok := tagFloat(tags, lonTag)
+	if !ok {
+		return nil, false
+	}
+	return []float64{lon, lat}, true
+}
+
+// tagFloat returns the numeric value of a tag, if the frame carries one.
+func tagFloat(tags map[int]*klvparser.KLVTag, tag int) (float64, bool) {
+	data, ok := tags[tag]
+	if !ok || data == nil {
+		return 0, false
+	}
+	value, ok := data.Value.(float64)
+	if !ok || value != value {
+		return 0, false
+	}
+	return value, true
+}
@@ -0,0 +1,68 @@
+package klvgeojson
+
+import (
+	"math"
+	"testing"
+
+	"github.com/StefanGrimminck/klvparser"
+)
+
+// decode encodes the tag values into a packet and decodes it again.
+func decode(t *testing.T, values map[int]float64) map[int]*klvparser.KLVTag {
+	t.Helper()
+	tags := make(map[int]*klvparser.KLVTag, len(values))
+	for tag, value := range values {
+		tags[tag] = &klvparser.KLVTag{Value: value}
+	}
+	packet, err := klvparser.Encode(tags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[int]*klvparser.KLVTag
+	p := klvparser.NewKLVParser(func(m map[int]*klvparser.KLVTag) { decoded = m })
+	if err := p.ProcessChunk(packet); err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
+
+func TestFrameFootprint(t *testing.T) {
+	values := map[int]float64{
+		2:  1700000000000000,
+		23: 10, 24: 20,
+		82: 10.1, 83: 19.9, // Upper left
+		84: 10.1, 85: 20.1, // Upper right
+		86: 9.9, 87: 20.1, // Lower right
+		88: 9.9, 89: 19.9, // Lower left
+	}
+	feature, ok := FrameFootprint(decode(t, values))
+	if !ok {
+		t.Fatal("no footprint from four corners")
+	}
+	if !feature.Geometry.IsPolygon() || len(feature.Geometry.Polygon) != 1 {
+		t.Fatalf("geometry = %+v, want a polygon with one ring", feature.Geometry)
+	}
+	// Positions are longitude first, and the ring closes on its first corner.
+	want := [][]float64{{19.9, 10.1}, {20.1, 10.1}, {20.1, 9.9}, {19.9, 9.9}, {19.9, 10.1}}
+	ring := feature.Geometry.Polygon[0]
+	if len(ring) != len(want) {
+		t.Fatalf("ring = %v, want %v", ring, want)
+	}
+	for i := range want {
+		if math.Abs(ring[i][0]-want[i][0]) > 1e-6 || math.Abs(ring[i][1]-want[i][1]) > 1e-6 {
+			t.Errorf("ring = %v, want %v", ring, want)
+			break
+		}
+	}
+	if center, ok := feature.Properties["center"].([]float64); !ok || math.Abs(center[0]-20) > 1e-6 || math.Abs(center[1]-10) > 1e-6 {
+		t.Errorf("center = %v, want [20 10]", feature.Properties["center"])
+	}
+	if stamp := feature.Properties["timestamp"]; stamp != "2023-11-14T22:13:20Z" {
+		t.Errorf("timestamp = %v, want 2023-11-14T22:13:20Z", stamp)
+	}
+
+	delete(values, 89)
+	if _, ok := FrameFootprint(decode(t, values)); ok {
+		t.Error("footprint without the fourth corner longitude")
+	}
+}
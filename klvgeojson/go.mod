@@ -0,0 +1,10 @@
+module github.com/StefanGrimminck/klvparser/klvgeojson
+
+go 1.23
+
+require (
+	github.com/StefanGrimminck/klvparser v0.0.0
+	github.com/paulmach/go.geojson v1.4.0
+)
+
+replace github.com/StefanGrimminck/klvparser => ../
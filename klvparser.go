@@ -2,68 +2,444 @@ package klvparser
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"math"
+	"sync/atomic"
+	"time"
 )
 
 // MISB0601UL represents the Universal Label for MISB ST 0601 metadata.
 var MISB0601UL = []byte{0x06, 0x0E, 0x2B, 0x34, 0x02, 0x0B, 0x01, 0x01, 0x0E, 0x01, 0x03, 0x01, 0x01, 0x00, 0x00, 0x00}
 
+const (
+	// universalKeyLength is the length of the Universal Label that keys each packet.
+	universalKeyLength = 16
+	// localKeyLength is the length of the keys within a local set, including nested sets.
+	localKeyLength = 1
+	// fillerTag is the reserved local tag used to pad packets; its items carry no metadata.
+	fillerTag = 0
+)
+
 // KLVParser is responsible for parsing MISB 0601 KLV data.
 type KLVParser struct {
-	buffer   []byte
-	callback func(map[int]*KLVTag)
+	// OnError, when set, receives the anomalies detected while decoding, including
+	// values that fail to decode or fall out of bounds and unknown tags. Without it
+	// they are written to Logger.
+	// Tag is 0 for errors that concern a whole packet or stream rather than a single tag.
+	OnError func(tag int, err error)
+
+	// Logger receives the anomalies when OnError is not set. NewKLVParser sets it
+	// to the standard logger; nil discards them.
+	Logger *log.Logger
+
+	// VerifyChecksum enables the checks on the checksum (tag 1) of each packet.
+	// A packet whose checksum does not match its contents is dropped and reported
+	// through OnError with a *ChecksumError, and one whose checksum is not the last
+	// item of the local set with ErrChecksumPosition. Off by default.
+	VerifyChecksum bool
+
+	// Units selects the units of decoded angles and distances, degrees and meters
+	// by default. Values are converted before their bounds are checked, and Unit
+	// of each tag names the resulting unit; MinValue and MaxValue stay in degrees
+	// and meters. The package's helpers, such as the geographic functions and
+	// Encode, accept tags in either unit.
+	Units Units
+
+	// MaxBufferSize, when positive, bounds the bytes buffered while waiting for a
+	// complete packet. Beyond it the oldest bytes are discarded and reported through
+	// OnError with ErrBufferOverflow, so a feed without valid packets cannot exhaust
+	// memory. Zero means no limit.
+	MaxBufferSize int
+
+	// tagMeta holds the metadata and the most recently decoded value of each tag.
+	// Every parser owns its table, so parsers can run concurrently.
+	tagMeta           map[int]*KLVTag
+	buffer            []byte
+	offset            int // Start of the bytes of buffer not consumed yet
+	callback          func(map[int]*KLVTag)
+	packetCallback    func(PacketInfo)
+	frameCallback     func(*Frame)
+	decodeTags        bool
+	fillMissing       []int
+	reuseTags         map[int]*KLVTag
+	fixedValueLength  int
+	chunkSize         int
+	lenientTimestamps bool
+	maxTagSeen        int
+	frozen            frozenState
+	checkTimes        bool
+	checkOrder        bool
+	normalizeCoords   bool
+	detectWrap        bool
+	attachUncertainty bool
+	lastLongitudes    map[int]float64
+	now               func() time.Time
+	throughput        throughputMeter
+	lastTimestamp     float64
+	sequence          uint64
+	segments          []Segment
+	handlers          map[int]func([]byte) interface{}
+	detectConcurrent  bool
+	inUse             int32
+}
+
+// PacketInfo describes the outer framing of a single KLV packet.
+type PacketInfo struct {
+	Length        int    // Total packet length, including key and length field
+	ValueLength   int    // Length of the packet's value (the local set)
+	Checksum      uint16 // Checksum carried in tag 1
+	HasChecksum   bool   // Whether the packet carries tag 1
+	ChecksumValid bool   // Whether the carried checksum matches the packet contents
+}
+
+// Frame is the result of decoding a single packet.
+type Frame struct {
+	Tags            map[int]*KLVTag // The decoded tags, as delivered to the tag callback
+	UnknownTags     int             // Number of tags not defined by MISB ST 0601
+	UnsupportedSets int             // Number of nested local sets delivered undecoded
+	Sequence        uint64          // Position of the frame in the stream, from 1, for spotting lost or reordered packets
 }
 
 // NewKLVParser initializes a new KLVParser with a callback function.
 func NewKLVParser(callback func(map[int]*KLVTag)) *KLVParser {
 	return &KLVParser{
-		buffer:   make([]byte, 0, 1024),
-		callback: callback,
+		buffer:     make([]byte, 0, 1024),
+		callback:   callback,
+		tagMeta:    newTagTable(tagDefinitions),
+		decodeTags: true,
+		Logger:     log.Default(),
+		now:        time.Now,
+	}
+}
+
+// Reset prepares the parser for a new stream. It drops the buffered bytes,
+// keeping the buffer's capacity, and forgets the values and history of the
+// previous stream: the last decoded tag values, the highest tag seen, the frame
+// sequence number, and the state of the timestamp, longitude and frozen
+// telemetry checks. Settings and callbacks are kept.
+func (p *KLVParser) Reset() {
+	p.buffer = p.buffer[:0]
+	p.offset = 0
+	for _, meta := range p.tagMeta {
+		meta.reset()
 	}
+	p.maxTagSeen = 0
+	p.sequence = 0
+	p.frozen = frozenState{threshold: p.frozen.threshold}
+	p.lastLongitudes = nil
+	p.lastTimestamp = 0
+	p.throughput = throughputMeter{}
+}
+
+// SetDecodeTags controls whether the tags of each packet are decoded.
+// When disabled, packets are only checked for framing and checksum and the
+// tag callback is not invoked; use SetPacketCallback to observe them.
+func (p *KLVParser) SetDecodeTags(decode bool) {
+	p.decodeTags = decode
+}
+
+// SetFillMissing sets a list of numeric tags that are always present in the
+// callback's tag map. Tags from the list that are absent in a packet are
+// delivered with a NaN value, so fixed-schema consumers see every column.
+func (p *KLVParser) SetFillMissing(tags []int) {
+	p.fillMissing = tags
+}
+
+// SetReuseMap makes the parser deliver every packet in the given map instead of
+// allocating a new one per packet. The map is cleared before each packet, so its
+// contents are only valid for the duration of the callback; consumers must copy
+// out anything they need to keep. Passing nil restores per-packet allocation.
+func (p *KLVParser) SetReuseMap(tags map[int]*KLVTag) {
+	p.reuseTags = tags
+}
+
+// SetCheckTagOrder enables a check that the tags of a packet appear in ascending
+// order, as MISB ST 0601 recommends. Out-of-order tags are reported through
+// OnError with ErrTagOrder. The checksum (tag 1), which closes the packet, is exempt.
+func (p *KLVParser) SetCheckTagOrder(check bool) {
+	p.checkOrder = check
+}
+
+// SetFixedValueLength configures the parser for packets without a BER length
+// field, where the key is followed by exactly length value bytes. A length of 0
+// restores the standard BER-encoded length.
+func (p *KLVParser) SetFixedValueLength(length int) {
+	p.fixedValueLength = length
+}
+
+// SetLenientTimestamps controls how a Precision Time Stamp (tag 2) of the wrong
+// width is handled. In lenient mode a 4-byte value is interpreted as whole seconds
+// since the epoch; otherwise any value that is not 8 bytes is reported through
+// OnError with ErrInvalidLength.
+func (p *KLVParser) SetLenientTimestamps(lenient bool) {
+	p.lenientTimestamps = lenient
+}
+
+// SetFrameCallback sets a callback that receives each decoded packet as a Frame,
+// after the tag callback has been invoked.
+func (p *KLVParser) SetFrameCallback(callback func(*Frame)) {
+	p.frameCallback = callback
+}
+
+// SetPacketCallback sets a callback that receives the framing information of
+// every packet, whether or not its tags are decoded.
+func (p *KLVParser) SetPacketCallback(callback func(PacketInfo)) {
+	p.packetCallback = callback
+}
+
+// SetDetectConcurrentUse enables a guard against calling ProcessChunk, or the
+// stream functions built on it, from several goroutines at once. A parser is not
+// safe for concurrent use; with the guard on, a call that overlaps another
+// returns ErrConcurrentUse, also reported through OnError, without touching the
+// buffered data. OnError may then be called from the overlapping goroutine.
+func (p *KLVParser) SetDetectConcurrentUse(detect bool) {
+	p.detectConcurrent = detect
+}
+
+// Buffered returns the number of bytes held while waiting for a complete packet.
+// A count that keeps growing points to a stream that no longer forms packets.
+func (p *KLVParser) Buffered() int {
+	return len(p.buffer) - p.offset
+}
+
+// MaxTagSeen returns the highest tag number encountered across the stream so far.
+func (p *KLVParser) MaxTagSeen() int {
+	return p.maxTagSeen
+}
+
+// Parse decodes every complete packet in data with a new parser and returns the
+// tags of each packet in order. Packets that fail to decode are logged and skipped;
+// a trailing incomplete packet is ignored.
+func Parse(data []byte) ([]map[int]*KLVTag, error) {
+	var frames []map[int]*KLVTag
+	parser := NewKLVParser(func(tags map[int]*KLVTag) {
+		frames = append(frames, tags)
+	})
+	if err := parser.ProcessChunk(data); err != nil {
+		return frames, err
+	}
+	return frames, nil
 }
 
 // ProcessChunk processes a chunk of data and extracts KLV packets.
 func (p *KLVParser) ProcessChunk(chunk []byte) error {
+	return p.processChunk(context.Background(), chunk)
+}
+
+// processChunk is ProcessChunk, stopping with ctx.Err() before the next packet
+// once ctx is done. Packets not yet decoded stay buffered.
+func (p *KLVParser) processChunk(ctx context.Context, chunk []byte) error {
+	if p.detectConcurrent {
+		if !atomic.CompareAndSwapInt32(&p.inUse, 0, 1) {
+			p.reportError(0, ErrConcurrentUse)
+			return ErrConcurrentUse
+		}
+		defer atomic.StoreInt32(&p.inUse, 0)
+	}
+
+	packets := 0
+	defer func() {
+		p.throughput.add(p.now(), len(chunk), packets)
+	}()
+
+	p.compactBuffer()
 	p.buffer = append(p.buffer, chunk...)
 	for {
-		startIndex := bytes.Index(p.buffer, MISB0601UL)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		data := p.buffer[p.offset:]
+		startIndex := bytes.Index(data, MISB0601UL)
 		if startIndex == -1 {
-			return nil
+			break
 		}
 
-		packet, remainingData, err := p.extractKLVPacket(p.buffer[startIndex:])
+		packet, remainingData, err := p.extractKLVPacket(data[startIndex:])
 		if err != nil {
-			return fmt.Errorf("failed to extract KLV packet: %w", err)
+			// Skip this key and look for the next packet.
+			p.reportError(0, fmt.Errorf("failed to extract KLV packet: %w", err))
+			p.offset += startIndex + 1
+			continue
 		}
 
-		if packet != nil {
-			if err := p.parseKLVPacket(packet); err != nil {
-				log.Printf("failed to parse KLV packet: %v", err)
-			}
+		if packet != nil && p.fixedValueLength == 0 && !p.itemsFillPacket(packet) &&
+			bytes.Contains(packet[1:], MISB0601UL) {
+			// The key matched inside the value of a packet whose start was missed,
+			// and the length that follows it spans the real packets. Resume at the
+			// next key rather than discard them.
+			p.reportError(0, ErrFalseKey)
+			p.offset += startIndex + 1
+			continue
+		}
 
-			p.buffer = remainingData
-		} else {
+		if packet == nil {
+			if next := bytes.Index(data[startIndex+1:], MISB0601UL); p.fixedValueLength == 0 && next >= 0 &&
+				!p.keyWithinItem(data[startIndex:], next+1) {
+				// A later key is buffered but does not lie within an item of this
+				// packet, so the length that follows this key is not a real one.
+				// Resume at the later key rather than wait for bytes that may never come.
+				p.reportError(0, ErrFalseKey)
+				p.offset += startIndex + 1
+				continue
+			}
 			break
 		}
+		err = p.parseKLVPacket(packet)
+		if errors.Is(err, ErrIncompletePacket) {
+			// The rest of the packet has not arrived yet; keep it buffered.
+			break
+		}
+		if err != nil {
+			// The packet is corrupt. Skip this key only, so a packet starting
+			// within the bytes it claimed is still found.
+			p.reportError(0, fmt.Errorf("failed to parse KLV packet: %w", err))
+			p.offset += startIndex + 1
+			continue
+		}
+		packets++
+		p.offset = len(p.buffer) - len(remainingData)
 	}
+	p.boundBuffer()
 	return nil
 }
 
+// itemsFillPacket reports whether the items of a packet's local set end exactly
+// at the end of the packet, as they do in every well-formed packet.
+func (p *KLVParser) itemsFillPacket(klvPacket []byte) bool {
+	valueStart, length := p.getValueStartAndLength(klvPacket)
+	if length < 0 {
+		return false
+	}
+	value := klvPacket[valueStart : valueStart+length]
+	for index := 0; index < len(value); {
+		index += localKeyLength
+		if index > len(value) {
+			return false
+		}
+		length, consumed, ok := decodeBERLength(value[index:])
+		if !ok {
+			return false
+		}
+		index += consumed + length
+		if index > len(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// keyWithinItem reports whether the key at offset key of an incomplete packet
+// lies entirely within the value of one of its items, as a key carried by a
+// packet does. It is false when the items before the key are malformed.
+func (p *KLVParser) keyWithinItem(data []byte, key int) bool {
+	valueStart, length := p.getValueStartAndLength(data)
+	if length < 0 {
+		return false
+	}
+	for index := valueStart; key >= index+localKeyLength; {
+		length, consumed, ok := decodeBERLength(data[index+localKeyLength:])
+		if !ok {
+			return false
+		}
+		start := index + localKeyLength + consumed
+		end := start + length
+		if key < start || key < end && key+universalKeyLength > end {
+			return false
+		}
+		if key+universalKeyLength <= end {
+			return true
+		}
+		index = end
+	}
+	return false
+}
+
+// compactThreshold is the number of consumed bytes at the front of the buffer
+// beyond which they are reclaimed by moving the unconsumed bytes to the front.
+const compactThreshold = 64 * 1024
+
+// compactBuffer reclaims the consumed bytes at the front of the buffer, so the
+// buffer's array is reused rather than grown. It does so without copying when
+// every byte was consumed, and otherwise only past compactThreshold, so decoding
+// packet after packet does not move or allocate memory.
+func (p *KLVParser) compactBuffer() {
+	switch {
+	case p.offset == len(p.buffer):
+		p.buffer = p.buffer[:0]
+	case p.offset >= compactThreshold:
+		p.buffer = append(p.buffer[:0], p.buffer[p.offset:]...)
+	default:
+		return
+	}
+	p.offset = 0
+}
+
+// boundBuffer discards the oldest buffered bytes once the buffer holds more than
+// MaxBufferSize bytes without a complete packet. The last bytes, too few to hold
+// a whole key, are kept so a key split across chunks still matches.
+func (p *KLVParser) boundBuffer() {
+	if p.MaxBufferSize <= 0 || p.Buffered() <= p.MaxBufferSize {
+		return
+	}
+	keep := universalKeyLength - 1
+	discarded := p.Buffered() - keep
+	// Move the kept bytes to the front so the discarded ones can be reused.
+	p.buffer = append(p.buffer[:0], p.buffer[p.offset+discarded:]...)
+	p.offset = 0
+	p.reportError(0, fmt.Errorf("%w: discarded %d bytes", ErrBufferOverflow, discarded))
+}
+
 // parseKLVPacket handles parsing of individual KLV packets.
 func (p *KLVParser) parseKLVPacket(klvPacket []byte) error {
-	if len(klvPacket) < 17 {
-		return fmt.Errorf("incomplete KLV packet with length %d, skipping", len(klvPacket))
+	if len(klvPacket) < universalKeyLength+1 {
+		return fmt.Errorf("%w: length %d", ErrIncompletePacket, len(klvPacket))
 	}
 
 	valueStart, length := p.getValueStartAndLength(klvPacket)
-	expectedTotalLength := 16 + 1 + length
+	if length < 0 {
+		return fmt.Errorf("%w: malformed packet length field", ErrInvalidLength)
+	}
+	if length == 0 {
+		// A packet without a local set carries no metadata; report it rather than
+		// firing the callback with an empty tag map.
+		return ErrEmptyPacket
+	}
+	expectedTotalLength := valueStart + length
 
 	if len(klvPacket) < expectedTotalLength {
-		return fmt.Errorf("KLV packet too short. Length: %d, Expected: %d", len(klvPacket), expectedTotalLength)
+		return fmt.Errorf("%w: length %d, expected %d", ErrIncompletePacket, len(klvPacket), expectedTotalLength)
+	}
+
+	info := PacketInfo{Length: len(klvPacket), ValueLength: length}
+	var computed uint16
+	if p.packetCallback != nil || p.VerifyChecksum {
+		var carried uint16
+		if carried, computed, info.HasChecksum = p.packetChecksum(klvPacket, valueStart, length); info.HasChecksum {
+			info.Checksum = carried
+			info.ChecksumValid = carried == computed
+		}
+	}
+	if p.packetCallback != nil {
+		p.packetCallback(info)
 	}
 
 	klvValue := klvPacket[valueStart : valueStart+length]
+	if p.VerifyChecksum {
+		if err := p.checkChecksumPosition(klvValue); err != nil {
+			return err
+		}
+		if info.HasChecksum && !info.ChecksumValid {
+			return &ChecksumError{Carried: info.Checksum, Computed: computed}
+		}
+	}
+
+	if !p.decodeTags {
+		return nil
+	}
+
 	p.parseMetadata(klvValue)
 
 	return nil
@@ -71,27 +447,84 @@ func (p *KLVParser) parseKLVPacket(klvPacket []byte) error {
 
 // parseMetadata processes the tag values in the KLV packet.
 func (p *KLVParser) parseMetadata(valueBytes []byte) {
-	parsedTags := make(map[int]*KLVTag)
-	index := 0
-	for index < len(valueBytes) {
-		tag := valueBytes[index]
-		index++
-		_, tagValue, newIndex := p.extractTagValue(valueBytes, index)
-		index = newIndex
-		p.processTag(tag, tagValue)
-		if tagMeta[int(tag)] != nil {
-			parsedTags[int(tag)] = tagMeta[int(tag)]
+	parsedTags := p.reuseTags
+	if parsedTags == nil {
+		parsedTags = make(map[int]*KLVTag)
+	} else {
+		for tag := range parsedTags {
+			delete(parsedTags, tag)
+		}
+	}
+	p.sequence++
+	frame := &Frame{Tags: parsedTags, Sequence: p.sequence}
+	p.segments = p.segments[:0]
+	previousTag := 0
+	for _, item := range p.splitLocalSet(valueBytes, localKeyLength) {
+		if item.key == fillerTag {
+			continue
+		}
+		if p.checkOrder && item.key != 1 {
+			if item.key < previousTag {
+				p.reportError(item.key, fmt.Errorf("%w: tag %d follows tag %d", ErrTagOrder, item.key, previousTag))
+			}
+			previousTag = item.key
+		}
+		if item.key > p.maxTagSeen {
+			p.maxTagSeen = item.key
+		}
+		if p.tagMeta[item.key] == nil {
+			frame.UnknownTags++
+		} else if unsupportedSetTags[item.key] && p.handlers[item.key] == nil {
+			frame.UnsupportedSets++
+		}
+		if meta := p.tagMeta[item.key]; meta != nil {
+			// Clear the previous packet's value so a tag that fails to decode
+			// does not repeat it.
+			meta.Value = nil
+			meta.Status = StatusOK
+			meta.Raw = append([]byte(nil), item.value...)
+		}
+		p.processTag(uint8(item.key), item.value)
+		if meta := p.tagMeta[item.key]; meta != nil {
+			// Deliver a copy so the map stays a snapshot of this packet.
+			parsedTags[item.key] = meta.clone()
+		}
+	}
+	for _, tag := range p.fillMissing {
+		if _, ok := parsedTags[tag]; ok {
+			continue
 		}
+		if definition, ok := tagDefinitions[tag]; ok {
+			missing := definition.newTag()
+			p.applyUnits(missing)
+			missing.Value = math.NaN()
+			parsedTags[tag] = missing
+		}
+	}
+	p.attachSDCCUncertainty(parsedTags)
+	p.normalizeCoordinates(parsedTags)
+	p.checkFrozen(parsedTags)
+	p.checkWraparound(parsedTags)
+	p.checkTimestamp(parsedTags)
+	p.checkConsistency(parsedTags)
+	if p.callback != nil {
+		p.callback(parsedTags)
+	}
+	if p.frameCallback != nil {
+		p.frameCallback(frame)
 	}
-	p.callback(parsedTags)
 }
 
 // processTag processes an individual tag based on its value and type.
 func (p *KLVParser) processTag(tag uint8, value []byte) {
+	if p.processCustomTag(int(tag), value) {
+		return
+	}
+
 	switch tag {
 	case 1:
 		// Tag 1: Checksum
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			if uintVal := extractUint16(val); uintVal != nil {
 				convertedVal := float64(*uintVal)
 				return &convertedVal
@@ -100,46 +533,54 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 		})
 	case 2:
 		// Tag 2: Precision Time Stamp
-		processValue(int(tag), value, func(val []byte) *float64 {
-			if uintVal := extractUint64(val); uintVal != nil {
-				convertedVal := float64(*uintVal)
-				return &convertedVal
+		if len(value) != 8 {
+			if p.lenientTimestamps && len(value) == 4 {
+				// Non-conformant encoders send whole seconds since the epoch.
+				p.processTime(int(tag), value, extractSecondsAsMicros)
+				break
 			}
-			return nil
-		})
+			if meta := p.tagMeta[int(tag)]; meta != nil {
+				meta.Value = nil
+				meta.micros, meta.hasMicros = 0, false
+				meta.Status = StatusTruncated
+			}
+			p.reportError(int(tag), fmt.Errorf("%w: precision time stamp has %d bytes, expected 8", ErrInvalidLength, len(value)))
+			break
+		}
+		p.processTime(int(tag), value, extractUint64)
 	case 3:
 		// Tag 3: Mission ID
 		val := string(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 4:
 		// Tag 4: Platform Tail Number
 		val := string(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 5:
 		// Tag 5: Platform Heading Angle
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint16(val, 360.0/65535.0)
 		})
 	case 6:
 		// Platform Pitch Angle: -20 to 20 degrees
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledInt16(val, 40.0/65535.0)
 		})
 
 	case 7:
-		// Platform Roll Angle: -20 to 20 degrees
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt16(val, 40.0/65535.0)
+		// Platform Roll Angle: -50 to 50 degrees
+		p.processValue(int(tag), value, func(val []byte) *float64 {
+			return extractScaledInt16(val, 100.0/65534.0)
 		})
 	case 8:
 		// Tag 8: Platform True Airspeed
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			if uintVal := extractUint8(val); uintVal != nil {
 				convertedVal := float64(*uintVal)
 				return &convertedVal
@@ -148,7 +589,7 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 		})
 	case 9:
 		// Tag 9: Platform Indicated Airspeed
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			if uintVal := extractUint8(val); uintVal != nil {
 				convertedVal := float64(*uintVal)
 				return &convertedVal
@@ -158,136 +599,131 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 	case 10:
 		// Tag 10: Platform Designation
 		val := string(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 11:
 		// Tag 11: Image Source Sensor
 		val := string(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 12:
 		// Tag 12: Image Coordinate System
 		val := string(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 13:
 		// Tag 13: Sensor Latitude
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt32(val, 180.0/(1<<31-1))
-		})
+		p.processValue(int(tag), value, extractLatitude)
 	case 14:
 		// Tag 14: Sensor Longitude
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt32(val, 360.0/(1<<31-1))
-		})
+		p.processValue(int(tag), value, extractLongitude)
 	case 15:
 		// Tag 15: Sensor True Altitude
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint16WithOffset(val, 19900.0/65535.0, -900.0)
 		})
 	case 16:
 		// Tag 16: Sensor Horizontal Field of View
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint16(val, 360.0/65535.0)
 		})
 	case 17:
 		// Tag 17: Sensor Vertical Field of View
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint16(val, 360.0/65535.0)
 		})
 	case 18:
 		// Tag 18: Sensor Relative Azimuth Angle
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint32(val, 360.0/4294967295.0)
 		})
 	case 19:
-		// Tag 19: Sensor Relative Elevation Angle
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt16(val, 40.0/65535.0)
+		// Tag 19: Sensor Relative Elevation Angle: -180 to 180 degrees
+		p.processValue(int(tag), value, func(val []byte) *float64 {
+			if len(val) == 2 {
+				return extractScaledInt16(val, 360.0/65534.0)
+			}
+			return extractScaledInt32(val, 360.0/4294967294.0)
 		})
 	case 20:
 		// Tag 20: Sensor Relative Roll Angle
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint32(val, 360.0/4294967295.0)
 		})
 	case 21:
 		// Tag 21: Slant Range
-		if val := extractUint32(value); val != nil {
-			convertedVal := float64(*val)
-			meta := tagMeta[int(tag)]
-			if meta != nil {
-				meta.Value = convertedVal
+		p.processValue(int(tag), value, func(val []byte) *float64 {
+			if uintVal := extractUint32(val); uintVal != nil {
+				convertedVal := float64(*uintVal)
+				return &convertedVal
 			}
-		}
+			return nil
+		})
 	case 22:
 		// Tag 22: Target Width
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint16(val, 10000.0/65535.0)
 		})
 	case 23:
 		// Tag 23: Frame Center Latitude
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt32(val, 90.0/(1<<31-1))
-		})
+		p.processValue(int(tag), value, extractLatitude)
 	case 24:
 		// Tag 24: Frame Center Longitude
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt32(val, 180.0/(1<<31-1))
-		})
+		p.processValue(int(tag), value, extractLongitude)
 	case 25:
 		// Tag 25: Frame Center Elevation
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint16WithOffset(val, 19900.0/65535.0, -900.0)
 		})
 	case 26:
 		// Tag 26: Offset Corner Latitude Point 1
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledInt16(val, 0.075/32767.0)
 		})
 	case 27:
 		// Tag 27: Offset Corner Longitude Point 1
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledInt16(val, 0.075/32767.0)
 		})
 	case 28:
 		// Tag 28: Offset Corner Latitude Point 2
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledInt16(val, 0.075/32767.0)
 		})
 	case 29:
 		// Tag 29: Offset Corner Longitude Point 2
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledInt16(val, 0.075/32767.0)
 		})
 	case 30:
 		// Tag 30: Offset Corner Latitude Point 3
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledInt16(val, 0.075/32767.0)
 		})
 	case 31:
 		// Tag 31: Offset Corner Longitude Point 3
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledInt16(val, 0.075/32767.0)
 		})
 	case 32:
 		// Tag 32: Offset Corner Latitude Point 4
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledInt16(val, 0.075/32767.0)
 		})
 	case 33:
 		// Tag 33: Offset Corner Longitude Point 4
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledInt16(val, 0.075/32767.0)
 		})
 	case 34:
 		// Tag 34: Target Error Estimate CE90
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			if uintVal := extractUint8(val); uintVal != nil {
 				convertedVal := float64(*uintVal)
 				return &convertedVal
@@ -295,12 +731,12 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 			return nil
 		})
 	case 35:
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint16(val, 4095.0/65535.0)
 		})
 	case 36:
-		// Tag 36: Generic Flag Data 01
-		processValue(int(tag), value, func(val []byte) *float64 {
+		// Tag 36: Wind Speed
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			if uintVal := extractUint8(val); uintVal != nil {
 				convertedVal := float64(*uintVal)
 				return &convertedVal
@@ -308,18 +744,18 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 			return nil
 		})
 	case 37:
-		// Tag 37: Security Local Metadata Set
-		processValue(int(tag), value, func(val []byte) *float64 {
+		// Tag 37: Static Pressure
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint16(val, 5000.0/65535.0)
 		})
 	case 38:
-		// Tag 38: Differential Pressure
-		processValue(int(tag), value, func(val []byte) *float64 {
+		// Tag 38: Density Altitude
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint16WithOffset(val, 19900.0/65535.0, -900.0)
 		})
 	case 39:
 		// Tag 39: Platform Angle of Attack
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			if intVal := extractInt8(val); intVal != nil {
 				convertedVal := float64(*intVal)
 				return &convertedVal
@@ -327,130 +763,113 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 			return nil
 		})
 	case 40:
-		// Tag 40: Platform Sideslip Angle
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt16(val, 40.0/65535.0)
-		})
+		// Tag 40: Target Location Latitude
+		p.processValue(int(tag), value, extractLatitude)
 	case 41:
-		// Tag 41: Airfield Barometric Pressure
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt32(val, 180.0/(1<<31-1))
-		})
+		// Tag 41: Target Location Longitude
+		p.processValue(int(tag), value, extractLongitude)
 	case 42:
-		// Tag 42: Airfield Elevation
-		processValue(int(tag), value, func(val []byte) *float64 {
+		// Tag 42: Target Location Elevation
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint16WithOffset(val, 19900.0/65535.0, -900.0)
 		})
 	case 43:
-		// Tag 43: Relative Humidity
-		processValue(int(tag), value, func(val []byte) *float64 {
-			if uintVal := extractUint8(val); uintVal != nil {
-				convertedVal := float64(*uintVal)
-				return &convertedVal
-			}
-			return nil
+		// Tag 43: Target Track Gate Width, in steps of two pixels
+		p.processValue(int(tag), value, func(val []byte) *float64 {
+			return extractScaledUint8(val, 2.0)
 		})
 	case 44:
-		// Tag 44: Platform Ground Speed
-		processValue(int(tag), value, func(val []byte) *float64 {
-			if uintVal := extractUint8(val); uintVal != nil {
-				convertedVal := float64(*uintVal)
-				return &convertedVal
-			}
-			return nil
+		// Tag 44: Target Track Gate Height, in steps of two pixels
+		p.processValue(int(tag), value, func(val []byte) *float64 {
+			return extractScaledUint8(val, 2.0)
 		})
 	case 45:
 		// Tag 45: Target Error Estimate - CE90
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint16(val, 4095.0/65535.0) // Resolution of 0.0624 meters
 		})
 	case 46:
 		// Tag 46: Target Error Estimate - LE90
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint16(val, 4095.0/65535.0) // Resolution of 0.0625 meters
 		})
 	case 47:
-		// Tag 47: Platform Call Sign
-		processValue(int(tag), value, func(val []byte) *float64 {
-			if uintVal := extractUint8(val); uintVal != nil {
-				convertedVal := float64(*uintVal)
-				return &convertedVal
+		// Tag 47: Generic Flag Data 01
+		meta := p.tagMeta[int(tag)]
+		flags := extractGenericFlags(value)
+		if flags == nil {
+			if meta != nil {
+				meta.Status = StatusTruncated
 			}
-			return nil
-		})
-	case 48:
-		// Tag 48: Weapon Load
-		val := extractHex(value)
-		meta := tagMeta[int(tag)]
-		if meta != nil {
-			meta.Value = val
+			p.reportError(int(tag), fmt.Errorf("%w: generic flag data has %d bytes, expected 1", ErrInvalidLength, len(value)))
+			break
 		}
-	case 49:
-		// Tag 49: Weapon Fired
-		val := extractHex(value)
-		meta := tagMeta[int(tag)]
 		if meta != nil {
-			meta.Value = val
+			meta.Value = *flags
 		}
+	case 48:
+		// Tag 48: Security Local Metadata Set
+		p.processSecuritySet(int(tag), value)
+	case 49:
+		// Tag 49: Differential Pressure
+		p.processValue(int(tag), value, func(val []byte) *float64 {
+			return extractScaledUint16(val, 5000.0/65535.0)
+		})
 	case 50:
-		processValue(int(tag), value, func(val []byte) *float64 {
+		// Platform Angle of Attack: -20 to 20 degrees
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledInt16(val, 40.0/65534.0)
 		})
 	case 51:
-		// Platform Vertical Speed
-		processValue(int(tag), value, func(val []byte) *float64 {
+		// Platform Vertical Speed: -180 to 180 meters per second
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledInt16(val, 360.0/65534.0)
 		})
 	case 52:
-		// Platform Sideslip Angle
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt16(val, 1.0)
+		// Platform Sideslip Angle: -20 to 20 degrees
+		p.processValue(int(tag), value, func(val []byte) *float64 {
+			return extractScaledInt16(val, 40.0/65534.0)
 		})
 	case 53:
-		// Airfield Barometric Pressure
-		processValue(int(tag), value, func(val []byte) *float64 {
+		// Tag 53: Airfield Barometric Pressure
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint16(val, 5000.0/65535.0)
 		})
 	case 54:
-		// Airfield Elevation
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledUint16(val, 1.0)
+		// Airfield Elevation: -900 to 19000 meters, same mapping as the other elevations
+		p.processValue(int(tag), value, func(val []byte) *float64 {
+			return extractScaledUint16WithOffset(val, 19900.0/65535.0, -900.0)
 		})
 	case 55:
-		// Relative Humidity
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledUint8(val, 1.0)
+		// Relative Humidity: 0 to 100 percent
+		p.processValue(int(tag), value, func(val []byte) *float64 {
+			return extractScaledUint8(val, 100.0/255.0)
 		})
 	case 56:
-		// Platform Ground Speed
-		processValue(int(tag), value, func(val []byte) *float64 {
+		// Platform Ground Speed: 0 to 255 meters per second
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint8(val, 1.0)
 		})
 	case 57:
-		// Ground Range
-		processValue(int(tag), value, func(val []byte) *float64 {
-			valUint32 := extractUint32(val)
-			if valUint32 != nil {
-				floatVal := float64(*valUint32)
-				return &floatVal
-			}
-			return nil
+		// Ground Range: 0 to 5000000 meters
+		p.processValue(int(tag), value, func(val []byte) *float64 {
+			return extractScaledUint32(val, 5000000.0/4294967295.0)
 		})
-
 	case 58:
-		// Platform Fuel Remaining
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledUint16(val, 1.0)
+		// Platform Fuel Remaining: 0 to 10000 kilograms
+		p.processValue(int(tag), value, func(val []byte) *float64 {
+			return extractScaledUint16(val, 10000.0/65535.0)
 		})
 	case 59:
 		// Platform Call Sign
 		val := string(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 60:
-		processValue(int(tag), value, func(val []byte) *float64 {
+		// Weapon Load: station, substation, weapon type and variant, one nibble each
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			valUint16 := extractUint16(val)
 			if valUint16 != nil {
 				floatVal := float64(*valUint16)
@@ -458,9 +877,9 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 			}
 			return nil
 		})
-
 	case 61:
-		processValue(int(tag), value, func(val []byte) *float64 {
+		// Weapon Fired: station and substation of the weapon fired
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			valUint8 := extractUint8(val)
 			if valUint8 != nil {
 				floatVal := float64(*valUint8)
@@ -469,7 +888,8 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 			return nil
 		})
 	case 62:
-		processValue(int(tag), value, func(val []byte) *float64 {
+		// Laser PRF Code
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			valUint16 := extractUint16(val)
 			if valUint16 != nil {
 				floatVal := float64(*valUint16)
@@ -478,7 +898,8 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 			return nil
 		})
 	case 63:
-		processValue(int(tag), value, func(val []byte) *float64 {
+		// Sensor Field of View Name: enumerated 0 to 8
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			valUint8 := extractUint8(val)
 			if valUint8 != nil {
 				floatVal := float64(*valUint8)
@@ -486,15 +907,14 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 			}
 			return nil
 		})
-
 	case 64:
 		// Platform Magnetic Heading
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint16(val, 360.0/65535.0)
 		})
 	case 65:
 		// UAS Datalink LS Version Number
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			if uintVal := extractUint8(val); uintVal != nil {
 				convertedVal := float64(*uintVal)
 				return &convertedVal
@@ -503,70 +923,56 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 		})
 	case 66:
 		// Deprecated
-		fmt.Println("Deprecated tag")
+		p.reportError(int(tag), fmt.Errorf("%w: %d", ErrDeprecatedTag, tag))
 	case 67:
 		// Alternate Platform Latitude
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt32(val, 90.0/(1<<31-1))
-		})
+		p.processValue(int(tag), value, extractLatitude)
 	case 68:
 		// Alternate Platform Longitude
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt32(val, 180.0/(1<<31-1))
-		})
+		p.processValue(int(tag), value, extractLongitude)
 	case 69:
 		// Alternate Platform Altitude
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint16WithOffset(val, 19900.0/65535.0, -900.0)
 		})
 	case 70:
 		// Alternate Platform Name
 		val := string(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 71:
 		// Alternate Platform Heading
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint16(val, 360.0/65535.0)
 		})
 	case 72:
 		// Event Start Time
-		processValue(int(tag), value, func(val []byte) *float64 {
-			if uintVal := extractUint64(val); uintVal != nil {
-				convertedVal := float64(*uintVal)
-				return &convertedVal
-			}
-			return nil
-		})
+		p.processTime(int(tag), value, extractUint64)
 	case 73:
 		// RVT Local Set
 		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 74:
 		// VMTI Local Set
-		val := extractHex(value)
-		meta := tagMeta[int(tag)]
-		if meta != nil {
-			meta.Value = val
-		}
+		p.processVMTI(int(tag), value)
 	case 75:
 		// Sensor Ellipsoid Height
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint16(val, 1.0)
 		})
 	case 76:
 		// Alternate Platform Ellipsoid Height
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledUint16(val, 1.0)
 		})
 	case 77:
 		// Operational Mode (Tag 77, uint8)
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			valUint8 := extractUint8(val)
 			if valUint8 != nil {
 				floatVal := float64(*valUint8)
@@ -575,194 +981,159 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 			return nil
 		})
 	case 78:
-		// Frame Center Height Above Ellipsoid
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledUint16(val, 1.0)
+		// Frame Center Height Above Ellipsoid: -900 to 19000 meters
+		p.processValue(int(tag), value, func(val []byte) *float64 {
+			return extractScaledUint16WithOffset(val, 19900.0/65535.0, -900.0)
 		})
 	case 79:
 		// Sensor North Velocity
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledInt16(val, 1.0)
 		})
 	case 80:
 		// Sensor East Velocity
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledInt16(val, 655.34/65535.0)
 		})
 	case 81:
 		// Image Horizon Pixel Pack
 		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 82:
 		// Corner Latitude Point 1 (Full)
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt32(val, 90.0/(1<<31-1))
-		})
+		p.processValue(int(tag), value, extractLatitude)
 	case 83:
 		// Corner Longitude Point 1 (Full)
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt32(val, 180.0/(1<<31-1))
-		})
+		p.processValue(int(tag), value, extractLongitude)
 	case 84:
 		// Corner Latitude Point 2 (Full)
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt32(val, 90.0/(1<<31-1))
-		})
+		p.processValue(int(tag), value, extractLatitude)
 	case 85:
 		// Corner Longitude Point 2 (Full)
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt32(val, 180.0/(1<<31-1))
-		})
+		p.processValue(int(tag), value, extractLongitude)
 	case 86:
 		// Corner Latitude Point 3 (Full)
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt32(val, 90.0/(1<<31-1))
-		})
+		p.processValue(int(tag), value, extractLatitude)
 	case 87:
 		// Corner Longitude Point 3 (Full)
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt32(val, 180.0/(1<<31-1))
-		})
+		p.processValue(int(tag), value, extractLongitude)
 	case 88:
 		// Corner Latitude Point 4 (Full)
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt32(val, 90.0/(1<<31-1))
-		})
+		p.processValue(int(tag), value, extractLatitude)
 	case 89:
 		// Corner Longitude Point 4 (Full)
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt32(val, 180.0/(1<<31-1))
-		})
+		p.processValue(int(tag), value, extractLongitude)
 	case 90:
 		// Platform Pitch Angle (Full)
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledInt32(val, 90.0/(1<<31-1))
 		})
 	case 91:
 		// Platform Roll Angle (Full)
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledInt32(val, 90.0/(1<<31-1))
 		})
 	case 92:
 		// Platform Angle of Attack (Full)
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			return extractScaledInt32(val, 90.0/(1<<31-1))
 		})
 	case 93:
 		// Platform Sideslip Angle (Full)
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractScaledInt32(val, 90.0/(1<<31-1))
+		p.processValue(int(tag), value, func(val []byte) *float64 {
+			return extractScaledInt32(val, 180.0/(1<<31-1))
 		})
 	case 94:
-		// MIIS Core Identifier
-		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		// MIIS Core Identifier: version, usage byte and the identifiers it announces
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
-			meta.Value = val
+			if id := extractMIISCoreID(value); id != nil {
+				meta.Value = *id
+			} else {
+				meta.Value = extractHex(value)
+			}
 		}
 	case 95:
 		// SAR Motion Imagery Local Set
 		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 96:
-		// Tag 96: Target Width Extended
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractIMAPB(val)
-		})
+		// Tag 96: Target Width Extended, 0 to 1500000 meters
+		p.processIMAPB(int(tag), value)
 	case 97:
 		// Range Image Local Set
 		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 98:
 		// Geo-Registration Local Set
 		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 99:
 		// Composite Imaging Local Set
 		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 100:
-		// Segment Local Set
-		val := extractHex(value)
-		meta := tagMeta[int(tag)]
-		if meta != nil {
-			meta.Value = val
-		}
+		// Segment Local Set: MISB ST 0601 tags grouped into one of the packet's segments
+		p.processSegmentSet(int(tag), value)
 	case 101:
 		// Amend Local Set
-		val := extractHex(value)
-		meta := tagMeta[int(tag)]
-		if meta != nil {
-			meta.Value = val
-		}
+		p.processAmendSet(int(tag), value)
 	case 102:
-		// SDCC-FLP
-		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		// SDCC-FLP: standard deviation and correlation coefficient matrix
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
-			meta.Value = val
+			if matrix := extractSDCC(value); matrix != nil {
+				meta.Value = *matrix
+			} else {
+				meta.Value = extractHex(value)
+			}
 		}
 	case 103:
-		// Tag 103: Density Altitude Extended
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractIMAPB(val)
-		})
+		// Tag 103: Density Altitude Extended, -900 to 40000 meters
+		p.processIMAPB(int(tag), value)
 
 	case 104:
-		// Tag 104: Sensor Ellipsoid Height Extended
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractIMAPB(val)
-		})
+		// Tag 104: Sensor Ellipsoid Height Extended, -900 to 40000 meters
+		p.processIMAPB(int(tag), value)
 
 	case 105:
-		// Tag 105: Alternate Platform Ellipsoid Height Extended
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractIMAPB(val)
-		})
+		// Tag 105: Alternate Platform Ellipsoid Height Extended, -900 to 40000 meters
+		p.processIMAPB(int(tag), value)
 	case 106:
 		// Stream Designator
 		val := string(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 107:
-		// Operational Base
-		val := string(value)
-		meta := tagMeta[int(tag)]
-		if meta != nil {
-			meta.Value = val
-		}
+		// Operational Base: free text or a coordinate pack
+		p.processTextOrLocation(int(tag), value)
 	case 108:
-		// Broadcast Source
-		val := string(value)
-		meta := tagMeta[int(tag)]
-		if meta != nil {
-			meta.Value = val
-		}
+		// Broadcast Source: free text or a coordinate pack
+		p.processTextOrLocation(int(tag), value)
 	case 109:
-		// Tag 109: Range to Recovery Location
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractIMAPB(val)
-		})
+		// Tag 109: Range to Recovery Location: 0 to 21000 kilometers. ST 0601 defines
+		// this range in kilometers rather than meters, so the value is kept in km.
+		p.processIMAPB(int(tag), value)
 	case 110:
 		// Time Airborne
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			if uintVal := extractUint32(val); uintVal != nil {
 				convertedVal := float64(*uintVal)
 				return &convertedVal
@@ -771,7 +1142,7 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 		})
 	case 111:
 		// Propulsion Unit Speed
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			if uintVal := extractUint32(val); uintVal != nil {
 				convertedVal := float64(*uintVal)
 				return &convertedVal
@@ -779,77 +1150,69 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 			return nil
 		})
 	case 112:
-		// Tag 112: Platform Course Angle
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractIMAPB(val)
-		})
+		// Tag 112: Platform Course Angle: 0 to 360 degrees
+		p.processIMAPB(int(tag), value)
 
 	case 113:
-		// Tag 113: Altitude Above Ground Level (AGL)
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractIMAPB(val)
-		})
+		// Tag 113: Altitude Above Ground Level (AGL), -900 to 40000 meters
+		p.processIMAPB(int(tag), value)
 
 	case 114:
-		// Tag 114: Radar Altimeter
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractIMAPB(val)
-		})
+		// Tag 114: Radar Altimeter, -900 to 40000 meters
+		p.processIMAPB(int(tag), value)
 	case 115:
-		// Control Command
-		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		// Control Command: command ID, command text and optional command time
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
-			meta.Value = val
+			if command := p.extractControlCommand(value); command != nil {
+				meta.Value = *command
+			} else {
+				meta.Value = extractHex(value)
+			}
 		}
 	case 116:
 		// Control Command Verification List
 		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 117:
-		// Tag 117: Sensor Azimuth Rate
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractIMAPB(val)
-		})
+		// Tag 117: Sensor Azimuth Rate, -1000 to 1000 degrees per second
+		p.processIMAPB(int(tag), value)
 
 	case 118:
-		// Tag 118: Sensor Elevation Rate
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractIMAPB(val)
-		})
+		// Tag 118: Sensor Elevation Rate, -1000 to 1000 degrees per second
+		p.processIMAPB(int(tag), value)
 
 	case 119:
-		// Tag 119: Sensor Roll Rate
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractIMAPB(val)
-		})
+		// Tag 119: Sensor Roll Rate, -1000 to 1000 degrees per second
+		p.processIMAPB(int(tag), value)
 
 	case 120:
-		// Tag 120: On-board MI Storage Percent Full
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractIMAPB(val)
-		})
+		// Tag 120: On-board MI Storage Percent Full, 0 to 100 %
+		p.processIMAPB(int(tag), value)
 
 	case 121:
-		// Active Wavelength List
-		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		// Active Wavelength List: wavelength identifiers preceded by a BER-OID count
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
-			meta.Value = val
+			if ids := extractWavelengthList(value); ids != nil {
+				meta.Value = ids
+			} else {
+				meta.Value = extractHex(value)
+			}
 		}
 	case 122:
 		// Country Codes
 		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 123:
 		// Number of NAVSATs in View
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			if uintVal := extractUint8(val); uintVal != nil {
 				convertedVal := float64(*uintVal)
 				return &convertedVal
@@ -858,7 +1221,7 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 		})
 	case 124:
 		// Positioning Method Source
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			if uintVal := extractUint8(val); uintVal != nil {
 				convertedVal := float64(*uintVal)
 				return &convertedVal
@@ -867,7 +1230,7 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 		})
 	case 125:
 		// Platform Status
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			if uintVal := extractUint8(val); uintVal != nil {
 				convertedVal := float64(*uintVal)
 				return &convertedVal
@@ -876,7 +1239,7 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 		})
 	case 126:
 		// Sensor Control Mode
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			if uintVal := extractUint8(val); uintVal != nil {
 				convertedVal := float64(*uintVal)
 				return &convertedVal
@@ -884,50 +1247,38 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 			return nil
 		})
 	case 127:
-		// Sensor Frame Rate Pack
-		val := extractHex(value)
-		meta := tagMeta[int(tag)]
-		if meta != nil {
-			meta.Value = val
-		}
+		// Sensor Frame Rate Pack: frame rate as a numerator and denominator
+		p.processFrameRate(int(tag), value)
 	case 128:
 		// Wavelengths List
 		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 129:
 		// Target ID
 		val := string(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 130:
 		// Airbase Locations
 		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 131:
 		// Take-off Time
-		processValue(int(tag), value, func(val []byte) *float64 {
-			if uintVal := extractUint64(val); uintVal != nil {
-				convertedVal := float64(*uintVal)
-				return &convertedVal
-			}
-			return nil
-		})
+		p.processTime(int(tag), value, extractUint64)
 	case 132:
-		// Tag 132: Transmission Frequency
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractIMAPB(val)
-		})
+		// Tag 132: Transmission Frequency, 1 to 99999 MHz
+		p.processIMAPB(int(tag), value)
 	case 133:
 		// On-board MI Storage Capacity
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			if uintVal := extractUint32(val); uintVal != nil {
 				convertedVal := float64(*uintVal)
 				return &convertedVal
@@ -935,20 +1286,18 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 			return nil
 		})
 	case 134:
-		// Tag 134: Zoom Percentage
-		processValue(int(tag), value, func(val []byte) *float64 {
-			return extractIMAPB(val)
-		})
+		// Tag 134: Zoom Percentage, 0 to 100 %
+		p.processIMAPB(int(tag), value)
 	case 135:
 		// Communications Method
 		val := string(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 136:
 		// Leap Seconds
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			if intVal := extractInt32(val); intVal != nil {
 				convertedVal := float64(*intVal)
 				return &convertedVal
@@ -957,7 +1306,7 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 		})
 	case 137:
 		// Correction Offset
-		processValue(int(tag), value, func(val []byte) *float64 {
+		p.processValue(int(tag), value, func(val []byte) *float64 {
 			if intVal := extractInt64(val); intVal != nil {
 				convertedVal := float64(*intVal)
 				return &convertedVal
@@ -966,47 +1315,52 @@ func (p *KLVParser) processTag(tag uint8, value []byte) {
 		})
 	case 138:
 		// Payload List
-		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
-			meta.Value = val
+			if payloads := p.extractPayloadList(value); payloads != nil {
+				meta.Value = payloads
+			} else {
+				meta.Value = extractHex(value)
+			}
 		}
 	case 139:
-		// Active Payloads
-		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		// Active Payloads: bit array of the active payload identifiers
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
-			meta.Value = val
+			meta.Value = extractActivePayloads(value)
 		}
 	case 140:
 		// Weapons Stores
 		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 141:
-		// Waypoint List
-		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		// Waypoint List: waypoint ID, prosecution order, info flags and location of each waypoint
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
-			meta.Value = val
+			if waypoints := p.extractWaypointList(value); waypoints != nil {
+				meta.Value = waypoints
+			} else {
+				meta.Value = extractHex(value)
+			}
 		}
 	case 142:
 		// View Domain
 		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	case 143:
 		// Metadata Substream ID Pack
 		val := extractHex(value)
-		meta := tagMeta[int(tag)]
+		meta := p.tagMeta[int(tag)]
 		if meta != nil {
 			meta.Value = val
 		}
 	default:
-		fmt.Printf("Warning: Unknown tag: %d\n", tag)
+		p.reportError(int(tag), fmt.Errorf("%w: %d", ErrUnknownTag, tag))
 	}
 }
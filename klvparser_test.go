@@ -0,0 +1,597 @@
+package klvparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// item encodes a local set item with a 1-byte key and a BER length.
+func item(tag byte, value ...byte) []byte {
+	return appendItem(nil, int(tag), value)
+}
+
+func u16(v uint16) []byte { return binary.BigEndian.AppendUint16(nil, v) }
+func u32(v uint32) []byte { return binary.BigEndian.AppendUint32(nil, v) }
+func u64(v uint64) []byte { return binary.BigEndian.AppendUint64(nil, v) }
+
+// pkt builds a MISB ST 0601 packet from items, closed by a valid checksum when withChecksum is set.
+func pkt(withChecksum bool, items ...[]byte) []byte {
+	var body []byte
+	for _, it := range items {
+		body = append(body, it...)
+	}
+	if withChecksum {
+		return finishPacket(body)
+	}
+	packet := append([]byte(nil), MISB0601UL...)
+	packet = append(packet, encodeBERLength(len(body))...)
+	return append(packet, body...)
+}
+
+// decodeOne decodes a single packet with a new parser and returns its tags.
+func decodeOne(t *testing.T, packet []byte) map[int]*KLVTag {
+	t.Helper()
+	var got map[int]*KLVTag
+	p := NewKLVParser(func(tags map[int]*KLVTag) { got = tags })
+	p.Logger = nil
+	if err := p.ProcessChunk(packet); err != nil {
+		t.Fatal(err)
+	}
+	if got == nil {
+		t.Fatal("packet was not decoded")
+	}
+	return got
+}
+
+func FuzzProcessChunk(f *testing.F) {
+	f.Add(pkt(true, item(5, 1, 2), item(13, 1, 2, 3, 4)))
+	f.Add(MISB0601UL[:10])
+	f.Fuzz(func(t *testing.T, data []byte) {
+		p := NewKLVParser(func(map[int]*KLVTag) {})
+		p.Logger = nil
+		p.MaxBufferSize = 1 << 16
+		p.ProcessChunk(data)
+		p.ProcessChunk(data)
+		if p.Buffered() > p.MaxBufferSize {
+			t.Fatalf("buffered %d bytes, limit %d", p.Buffered(), p.MaxBufferSize)
+		}
+		DecodeBatch([][]byte{data}, 1)
+	})
+}
+
+func TestFailedTagDoesNotRepeatPreviousValue(t *testing.T) {
+	var got map[int]*KLVTag
+	p := NewKLVParser(func(tags map[int]*KLVTag) { got = tags })
+	p.Logger = nil
+	if err := p.ProcessChunk(pkt(true, item(21, u32(1000)...), item(47, 0x01))); err != nil {
+		t.Fatal(err)
+	}
+	if got[21].Value != 1000.0 || got[47].Value.(GenericFlags).Raw != 0x01 {
+		t.Fatalf("first packet: slant range %v, flags %v", got[21].Value, got[47].Value)
+	}
+	if err := p.ProcessChunk(pkt(true, item(21, 0, 0, 1), item(47))); err != nil {
+		t.Fatal(err)
+	}
+	for _, tag := range []int{21, 47} {
+		if got[tag].Value != nil || got[tag].Status != StatusTruncated {
+			t.Errorf("tag %d: value %v, status %v; want nil, StatusTruncated", tag, got[tag].Value, got[tag].Status)
+		}
+	}
+}
+
+func TestSlantRangeUnits(t *testing.T) {
+	var got map[int]*KLVTag
+	p := NewKLVParser(func(tags map[int]*KLVTag) { got = tags })
+	p.Units.Distance = DistanceFeet
+	if err := p.ProcessChunk(pkt(true, item(21, u32(3048)...))); err != nil {
+		t.Fatal(err)
+	}
+	if v := got[21].Value.(float64); got[21].Unit != "ft" || math.Abs(v-10000) > 1e-9 {
+		t.Errorf("slant range = %v %s, want 10000 ft", v, got[21].Unit)
+	}
+}
+
+func TestFalseKeyWithHugeLength(t *testing.T) {
+	var got []map[int]*KLVTag
+	var errs []error
+	p := NewKLVParser(func(tags map[int]*KLVTag) { got = append(got, tags) })
+	p.OnError = func(tag int, err error) { errs = append(errs, err) }
+	// The tail of a missed packet's blob holds a key whose length claims 16 MiB.
+	tail := append(append([]byte(nil), MISB0601UL...), 0x83, 0xFF, 0xFF, 0xFF, 0x90, 0x01)
+	if err := p.ProcessChunk(append(tail, pkt(true, item(5, 2, 2))...)); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0][5].Raw[0] != 2 {
+		t.Fatalf("decoded %d packets, want the packet after the false key", len(got))
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrFalseKey) {
+		t.Errorf("errors = %v, want one ErrFalseKey", errs)
+	}
+}
+
+func TestSplitPacketCarryingKey(t *testing.T) {
+	var got []map[int]*KLVTag
+	p := NewKLVParser(func(tags map[int]*KLVTag) { got = append(got, tags) })
+	p.OnError = func(tag int, err error) { t.Errorf("unexpected error: %v", err) }
+	blob := append(append([]byte(nil), MISB0601UL...), 3, 5, 1, 7)
+	packet := pkt(true, item(140, blob...), item(5, 1, 1))
+	for _, chunk := range [][]byte{packet[:len(packet)-5], packet[len(packet)-5:]} {
+		if err := p.ProcessChunk(chunk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(got) != 1 || got[0][5].Raw[0] != 1 {
+		t.Fatalf("decoded %d packets, want the packet carrying the key", len(got))
+	}
+}
+
+func TestTags50To64(t *testing.T) {
+	tests := []struct {
+		tag   byte
+		value []byte
+		want  interface{}
+	}{
+		{50, []byte{0xC8, 0x83}, -8.670309},
+		{51, []byte{0xD3, 0xFE}, -61.88787},
+		{52, []byte{0xDF, 0x79}, -5.082553},
+		{53, []byte{0x6A, 0xF4}, 2088.96},
+		{54, []byte{0x71, 0xC2}, 7943.027},
+		{55, []byte{0xCF}, 81.17647},
+		{56, []byte{0x8C}, 140.0},
+		{57, []byte{0xB3, 0x8E, 0xAC, 0xF1}, 3506979.0},
+		{58, []byte{0xA4, 0x5D}, 6420.539},
+		{59, []byte("TOP GUN"), "TOP GUN"},
+		{60, []byte{0xAF, 0xD2}, 45010.0},
+		{61, []byte{0xBA}, 186.0},
+		{62, []byte{0x06, 0x0F}, 1551.0},
+		{63, []byte{0x02}, 2.0},
+		{64, []byte{0xDD, 0xC5}, 311.8682},
+	}
+	for _, tt := range tests {
+		tag := decodeOne(t, pkt(true, item(tt.tag, tt.value...)))[int(tt.tag)]
+		if tag.Status != StatusOK {
+			t.Errorf("tag %d: status %v", tt.tag, tag.Status)
+			continue
+		}
+		want, ok := tt.want.(float64)
+		if !ok {
+			if tag.Value != tt.want {
+				t.Errorf("tag %d = %v, want %v", tt.tag, tag.Value, tt.want)
+			}
+			continue
+		}
+		// The vectors are given to seven significant digits.
+		if got, _ := tag.Value.(float64); math.Abs(got-want) > 1e-6*math.Max(1, math.Abs(want)) {
+			t.Errorf("tag %d = %v, want %v", tt.tag, tag.Value, want)
+		}
+	}
+}
+
+func TestTagStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		item   []byte
+		status TagStatus
+	}{
+		{"complete time stamp", item(2, u64(1)...), StatusOK},
+		{"truncated time stamp", item(2, 0, 0, 1), StatusTruncated},
+		{"out of range latitude", item(13, 0x80, 0, 0, 0), StatusOutOfBounds},
+		{"latitude at the top of its range", item(13, 0x7F, 0xFF, 0xFF, 0xFF), StatusOK},
+		{"infinite altitude", item(113, 2, 0xC8, 0), StatusOutOfBounds},
+		{"NaN altitude", item(113, 2, 0xD0, 0), StatusNull},
+	}
+	for _, tt := range tests {
+		var errs []error
+		var got map[int]*KLVTag
+		p := NewKLVParser(func(tags map[int]*KLVTag) { got = tags })
+		p.OnError = func(tag int, err error) { errs = append(errs, err) }
+		if err := p.ProcessChunk(pkt(true, tt.item)); err != nil {
+			t.Fatal(err)
+		}
+		tag := got[int(tt.item[0])]
+		if tag.Status != tt.status {
+			t.Errorf("%s: status %v, want %v", tt.name, tag.Status, tt.status)
+		}
+		if (tag.Value == nil) != (tt.status != StatusOK) {
+			t.Errorf("%s: value %v with status %v", tt.name, tag.Value, tag.Status)
+		}
+		if tt.status == StatusTruncated && (tag.Value != nil || len(errs) != 1 || !errors.Is(errs[0], ErrInvalidLength)) {
+			t.Errorf("%s: value %v, errors %v; want no value and one ErrInvalidLength", tt.name, tag.Value, errs)
+		}
+	}
+}
+
+func TestSetDecodeTags(t *testing.T) {
+	decoded := 0
+	var infos []PacketInfo
+	p := NewKLVParser(func(map[int]*KLVTag) { decoded++ })
+	p.SetDecodeTags(false)
+	p.SetPacketCallback(func(info PacketInfo) { infos = append(infos, info) })
+	good := pkt(true, item(5, 1, 2))
+	bad := append([]byte(nil), good...)
+	bad[len(bad)-1]++
+	if err := p.ProcessChunk(append(append(good, bad...), good...)); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != 0 {
+		t.Errorf("tag callback fired %d times with decoding disabled", decoded)
+	}
+	if len(infos) != 3 {
+		t.Fatalf("packet callback fired %d times, want 3", len(infos))
+	}
+	for i, info := range infos {
+		if info.Length != len(good) || !info.HasChecksum || info.ChecksumValid != (i != 1) {
+			t.Errorf("packet %d: %+v", i, info)
+		}
+	}
+}
+
+// benchmarkStream returns a chunk of n packets carrying a typical set of tags.
+func benchmarkStream(n int) []byte {
+	packet := pkt(true,
+		item(2, u64(1700000000000000)...), item(5, u16(0x8000)...), item(6, u16(0x0100)...),
+		item(7, u16(0x0200)...), item(13, u32(0x20000000)...), item(14, u32(0x20000000)...),
+		item(15, u16(0x4000)...), item(23, u32(0x20000000)...), item(24, u32(0x20000000)...),
+		item(65, 19))
+	return bytes.Repeat(packet, n)
+}
+
+func BenchmarkDecodeTags(b *testing.B) {
+	for _, decode := range []bool{true, false} {
+		b.Run(fmt.Sprintf("decode=%v", decode), func(b *testing.B) {
+			stream := benchmarkStream(100)
+			p := NewKLVParser(func(map[int]*KLVTag) {})
+			p.SetDecodeTags(decode)
+			p.SetPacketCallback(func(PacketInfo) {})
+			b.SetBytes(int64(len(stream)))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				p.ProcessChunk(stream)
+			}
+		})
+	}
+}
+
+func TestSetFillMissing(t *testing.T) {
+	var got map[int]*KLVTag
+	p := NewKLVParser(func(tags map[int]*KLVTag) { got = tags })
+	p.SetFillMissing([]int{13, 14})
+	if err := p.ProcessChunk(pkt(true, item(13, u32(0x20000000)...))); err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(got[13].Value.(float64)-22.5) > 1e-6 {
+		t.Errorf("latitude = %v, want 22.5", got[13].Value)
+	}
+	longitude := got[14]
+	if longitude == nil {
+		t.Fatal("missing longitude not delivered")
+	}
+	if v, ok := longitude.Value.(float64); !ok || !math.IsNaN(v) {
+		t.Errorf("missing longitude = %v, want NaN", longitude.Value)
+	}
+	if longitude.Name != "Sensor Longitude" || longitude.Unit != "°" {
+		t.Errorf("missing longitude = %+v, want its name and unit", longitude)
+	}
+}
+
+func TestZeroLengthPacket(t *testing.T) {
+	var got []map[int]*KLVTag
+	var errs []error
+	p := NewKLVParser(func(tags map[int]*KLVTag) { got = append(got, tags) })
+	p.OnError = func(tag int, err error) { errs = append(errs, err) }
+	empty := append(append([]byte(nil), MISB0601UL...), 0x00)
+	if err := p.ProcessChunk(append(empty, pkt(true, item(5, 1, 2))...)); err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrEmptyPacket) {
+		t.Errorf("errors = %v, want one ErrEmptyPacket", errs)
+	}
+	if len(got) != 1 || got[0][5] == nil {
+		t.Errorf("callback fired %d times, want once for the packet after the empty one", len(got))
+	}
+}
+
+func TestSetReuseMap(t *testing.T) {
+	reused := make(map[int]*KLVTag)
+	var headings []float64
+	var sameMap bool
+	p := NewKLVParser(func(tags map[int]*KLVTag) {
+		sameMap = reflect.ValueOf(tags).UnsafePointer() == reflect.ValueOf(reused).UnsafePointer()
+		headings = append(headings, tags[5].Value.(float64))
+		if len(headings) == 2 && tags[13] != nil {
+			t.Error("latitude of the first packet left in the reused map")
+		}
+	})
+	p.SetReuseMap(reused)
+	chunk := append(pkt(true, item(5, u16(0)...), item(13, u32(1)...)), pkt(true, item(5, u16(0x8000)...))...)
+	if err := p.ProcessChunk(chunk); err != nil {
+		t.Fatal(err)
+	}
+	if !sameMap {
+		t.Error("callback did not receive the reused map")
+	}
+	if len(headings) != 2 || headings[0] != 0 || math.Abs(headings[1]-0x8000*360.0/65535) > 1e-9 {
+		t.Errorf("headings = %v", headings)
+	}
+}
+
+func BenchmarkReuseMap(b *testing.B) {
+	for _, reuse := range []bool{false, true} {
+		b.Run(fmt.Sprintf("reuse=%v", reuse), func(b *testing.B) {
+			stream := benchmarkStream(100)
+			p := NewKLVParser(func(map[int]*KLVTag) {})
+			if reuse {
+				p.SetReuseMap(make(map[int]*KLVTag))
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				p.ProcessChunk(stream)
+			}
+		})
+	}
+}
+
+func TestMaxTagSeen(t *testing.T) {
+	p := NewKLVParser(func(map[int]*KLVTag) {})
+	chunk := append(pkt(true, item(5, 1, 2), item(143, 1, 2, 3)), pkt(true, item(13, u32(1)...))...)
+	if err := p.ProcessChunk(chunk); err != nil {
+		t.Fatal(err)
+	}
+	if got := p.MaxTagSeen(); got != 143 {
+		t.Errorf("MaxTagSeen() = %d, want 143", got)
+	}
+	p.Reset()
+	if got := p.MaxTagSeen(); got != 0 {
+		t.Errorf("MaxTagSeen() after Reset = %d, want 0", got)
+	}
+}
+
+func TestElevationTags42And54(t *testing.T) {
+	// 0x8000 lies halfway along -900 to 19000 m.
+	tags := decodeOne(t, pkt(true, item(42, u16(0x8000)...), item(54, u16(0x8000)...)))
+	want := -900 + 0x8000*19900.0/65535
+	for _, tt := range []struct {
+		tag  int
+		name string
+	}{{42, "Target Location Elevation"}, {54, "Airfield Elevation"}} {
+		got := tags[tt.tag]
+		if got.Name != tt.name || got.Unit != "m" || got.Status != StatusOK {
+			t.Errorf("tag %d = %+v, want %s in m", tt.tag, got, tt.name)
+		}
+		if v := got.Value.(float64); math.Abs(v-want) > 1e-9 {
+			t.Errorf("tag %d = %v, want %v", tt.tag, v, want)
+		}
+	}
+}
+
+func TestTrackGateWidthAndHumidity(t *testing.T) {
+	tags := decodeOne(t, pkt(true, item(43, 0x64), item(55, 0xFF)))
+	if gate := tags[43]; gate.Name != "Target Track Gate Width" || gate.Value != 200.0 || gate.Unit != "pixels" {
+		t.Errorf("tag 43 = %+v, want a track gate width of 200 pixels", gate)
+	}
+	humidity := tags[55]
+	if humidity.Name != "Relative Humidity" || humidity.Unit != "%" || humidity.Status != StatusOK {
+		t.Errorf("tag 55 = %+v, want Relative Humidity in %%", humidity)
+	}
+	if v := humidity.Value.(float64); math.Abs(v-100) > 1e-9 {
+		t.Errorf("humidity = %v, want 100", v)
+	}
+}
+
+func TestDetectConcurrentUse(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	p := NewKLVParser(func(map[int]*KLVTag) {
+		close(entered)
+		<-release
+	})
+	p.SetDetectConcurrentUse(true)
+	var reported []error
+	p.OnError = func(tag int, err error) { reported = append(reported, err) }
+
+	done := make(chan error)
+	go func() { done <- p.ProcessChunk(pkt(true, item(5, 1, 1))) }()
+	<-entered
+	err := p.ProcessChunk(pkt(true, item(5, 2, 2)))
+	close(release)
+	if firstErr := <-done; firstErr != nil {
+		t.Fatalf("first ProcessChunk: %v", firstErr)
+	}
+	if !errors.Is(err, ErrConcurrentUse) {
+		t.Errorf("concurrent ProcessChunk returned %v, want ErrConcurrentUse", err)
+	}
+	if len(reported) != 1 || !errors.Is(reported[0], ErrConcurrentUse) {
+		t.Errorf("reported %v, want ErrConcurrentUse", reported)
+	}
+}
+
+func TestFrameSequence(t *testing.T) {
+	var sequence []uint64
+	p := NewKLVParser(nil)
+	p.SetFrameCallback(func(frame *Frame) { sequence = append(sequence, frame.Sequence) })
+	packet := pkt(true, item(5, 1, 1))
+	if err := p.ProcessChunk(bytes.Repeat(packet, 3)); err != nil {
+		t.Fatal(err)
+	}
+	p.Reset()
+	if err := p.ProcessChunk(packet); err != nil {
+		t.Fatal(err)
+	}
+	if want := []uint64{1, 2, 3, 1}; !reflect.DeepEqual(sequence, want) {
+		t.Errorf("sequence = %v, want %v", sequence, want)
+	}
+}
+
+func TestBuffered(t *testing.T) {
+	p := NewKLVParser(func(map[int]*KLVTag) {})
+	packet := pkt(true, item(5, 1, 1))
+	if err := p.ProcessChunk(packet[:20]); err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Buffered(); got != 20 {
+		t.Errorf("Buffered with a partial packet = %d, want 20", got)
+	}
+	if err := p.ProcessChunk(packet[20:]); err != nil {
+		t.Fatal(err)
+	}
+	if got := p.Buffered(); got != 0 {
+		t.Errorf("Buffered after the packet completed = %d, want 0", got)
+	}
+}
+
+func TestLSVersion(t *testing.T) {
+	tags := decodeOne(t, pkt(true, item(65, 8), item(7, u16(0x3FFF)...)))
+	if got := tags[65].Value; got != 8.0 {
+		t.Errorf("tag 65 = %v, want 8", got)
+	}
+	// Every LS version is decoded with the scaling of the latest ST 0601 revision.
+	if got, want := tags[7].Value.(float64), 0x3FFF*100.0/65534.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("roll = %v, want %v", got, want)
+	}
+}
+
+func TestCallbackOwnsTags(t *testing.T) {
+	// Each callback checks the heading it is handed, then mutates it; no later
+	// frame, of the same parser or another, may see the mutation.
+	callback := func(tags map[int]*KLVTag) {
+		heading := tags[5]
+		if heading.Name != "Platform Heading Angle" || heading.Unit != "°" || heading.MaxValue != 360 || heading.Value != 0x8000*360.0/65535.0 {
+			t.Errorf("heading = %+v, mutated by an earlier callback", heading)
+		}
+		heading.Name, heading.Unit, heading.MaxValue, heading.Value = "changed", "changed", -1, -1.0
+	}
+	a := NewKLVParser(callback)
+	b := NewKLVParser(callback)
+	packet := pkt(true, item(5, u16(0x8000)...))
+	for _, p := range []*KLVParser{a, a, b} {
+		if err := p.ProcessChunk(packet); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSensorRelativeElevation(t *testing.T) {
+	tests := []struct {
+		name  string
+		value []byte
+		want  interface{}
+	}{
+		{"2-byte top", u16(0x7FFF), 180.0},
+		{"2-byte bottom", u16(0x8001), -180.0},
+		{"2-byte out of range", u16(0x8000), nil},
+		{"4-byte top", u32(0x7FFFFFFF), 180.0},
+		{"4-byte bottom", u32(0x80000001), -180.0},
+		{"4-byte out of range", u32(0x80000000), nil},
+	}
+	for _, tt := range tests {
+		tag := decodeOne(t, pkt(true, item(19, tt.value...)))[19]
+		if tt.want == nil {
+			if tag.Value != nil || !tag.OutOfRange || tag.Status != StatusOutOfBounds {
+				t.Errorf("%s: value %v, out of range %v, status %v; want the out of range indicator",
+					tt.name, tag.Value, tag.OutOfRange, tag.Status)
+			}
+			continue
+		}
+		if got, ok := tag.Value.(float64); !ok || math.Abs(got-tt.want.(float64)) > 1e-9 {
+			t.Errorf("%s: elevation = %v, want %v", tt.name, tag.Value, tt.want)
+		}
+	}
+}
+
+func TestFillerItemsSkipped(t *testing.T) {
+	var errs []error
+	var tags map[int]*KLVTag
+	var frame *Frame
+	p := NewKLVParser(func(m map[int]*KLVTag) { tags = m })
+	p.OnError = func(tag int, err error) { errs = append(errs, err) }
+	p.SetFrameCallback(func(f *Frame) { frame = f })
+	packet := pkt(true, item(0, 0, 0, 0), item(5, u16(0x8000)...), item(0))
+	if err := p.ProcessChunk(packet); err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 0 {
+		t.Errorf("errors = %v, want none for filler items", errs)
+	}
+	if _, ok := tags[0]; ok || tags[5] == nil {
+		t.Errorf("tags = %v, want the heading without filler", tags)
+	}
+	if frame.UnknownTags != 0 {
+		t.Errorf("unknown tags = %d, want filler not counted", frame.UnknownTags)
+	}
+}
+
+func TestFrameUnknownTags(t *testing.T) {
+	var frame *Frame
+	p := NewKLVParser(nil)
+	p.OnError = func(int, error) {}
+	p.SetFrameCallback(func(f *Frame) { frame = f })
+	packet := pkt(true, item(5, u16(0x8000)...), item(73, 1, 1, 0), item(250, 1), item(251, 2, 3))
+	if err := p.ProcessChunk(packet); err != nil {
+		t.Fatal(err)
+	}
+	if frame.UnknownTags != 2 {
+		t.Errorf("unknown tags = %d, want 2", frame.UnknownTags)
+	}
+	if frame.UnsupportedSets != 1 {
+		t.Errorf("unsupported sets = %d, want 1", frame.UnsupportedSets)
+	}
+}
+
+func TestCheckTagOrder(t *testing.T) {
+	tests := []struct {
+		name   string
+		packet []byte
+		errors int
+	}{
+		// The checksum closing the packet is exempt.
+		{"ascending", pkt(true, item(2, u64(1)...), item(5, 1, 1), item(13, u32(1)...)), 0},
+		{"out of order", pkt(true, item(2, u64(1)...), item(13, u32(1)...), item(5, 1, 1)), 1},
+	}
+	for _, tt := range tests {
+		var errs []error
+		p := NewKLVParser(func(map[int]*KLVTag) {})
+		p.OnError = func(tag int, err error) { errs = append(errs, err) }
+		p.SetCheckTagOrder(true)
+		if err := p.ProcessChunk(tt.packet); err != nil {
+			t.Fatal(err)
+		}
+		if len(errs) != tt.errors {
+			t.Errorf("%s: errors %v, want %d", tt.name, errs, tt.errors)
+		}
+		for _, err := range errs {
+			if !errors.Is(err, ErrTagOrder) {
+				t.Errorf("%s: error %v, want ErrTagOrder", tt.name, err)
+			}
+		}
+	}
+}
+
+func TestSetFixedValueLength(t *testing.T) {
+	// Each packet is the UL followed directly by its value, without a BER length.
+	fixed := func(heading uint16) []byte {
+		packet := append([]byte(nil), MISB0601UL...)
+		packet = append(packet, item(5, u16(heading)...)...)
+		return append(packet, item(13, u32(0x20000000)...)...)
+	}
+	var headings []float64
+	p := NewKLVParser(func(tags map[int]*KLVTag) {
+		if tags[13] == nil {
+			t.Errorf("tags = %v, want the latitude", tags)
+		}
+		headings = append(headings, tags[5].Value.(float64))
+	})
+	p.SetFixedValueLength(10)
+	stream := append(fixed(0), fixed(0x8000)...)
+	if err := p.ProcessChunk(stream); err != nil {
+		t.Fatal(err)
+	}
+	if want := []float64{0, 0x8000 * 360.0 / 65535.0}; !reflect.DeepEqual(headings, want) {
+		t.Errorf("headings = %v, want %v", headings, want)
+	}
+}
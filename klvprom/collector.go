@@ -0,0 +1,87 @@
+// Package klvprom exposes decoded MISB ST 0601 tags as Prometheus metrics.
+// It is a separate module so the core parser stays free of dependencies.
+package klvprom
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/StefanGrimminck/klvparser"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// tagValue is the latest value seen for a numeric tag.
+type tagValue struct {
+	name  string
+	value float64
+}
+
+// Collector is a prometheus.Collector reporting the latest value of every numeric
+// tag and the number of decode failures per tag.
+type Collector struct {
+	mu       sync.Mutex
+	values   map[int]tagValue
+	failures map[int]float64
+
+	valueDesc   *prometheus.Desc
+	failureDesc *prometheus.Desc
+}
+
+// NewCollector creates a Collector. Feed it with ObserveFrame from the parser
+// callback and ObserveError from the parser's OnError hook.
+func NewCollector() *Collector {
+	return &Collector{
+		values:   make(map[int]tagValue),
+		failures: make(map[int]float64),
+		valueDesc: prometheus.NewDesc(
+			"klv_tag_value",
+			"Latest decoded value of a numeric MISB ST 0601 tag.",
+			[]string{"tag", "code", "name"}, nil,
+		),
+		failureDesc: prometheus.NewDesc(
+			"klv_decode_failures_total",
+			"Number of decode failures reported for a MISB ST 0601 tag; tag 0 covers whole packets.",
+			[]string{"tag"}, nil,
+		),
+	}
+}
+
+// ObserveFrame records the numeric values of a decoded frame.
+func (c *Collector) ObserveFrame(tags map[int]*klvparser.KLVTag) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for tag, data := range tags {
+		if data == nil {
+			continue
+		}
+		if value, ok := data.Value.(float64); ok {
+			c.values[tag] = tagValue{name: data.Name, value: value}
+		}
+	}
+}
+
+// ObserveError counts a decode failure; its signature matches KLVParser.OnError.
+func (c *Collector) ObserveError(tag int, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures[tag]++
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.valueDesc
+	ch <- c.failureDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for tag, v := range c.values {
+		ch <- prometheus.MustNewConstMetric(c.valueDesc, prometheus.GaugeValue, v.value,
+			strconv.Itoa(tag), klvparser.TagCode(tag), v.name)
+	}
+	for tag, count := range c.failures {
+		ch <- prometheus.MustNewConstMetric(c.failureDesc, prometheus.CounterValue, count, strconv.Itoa(tag))
+	}
+}
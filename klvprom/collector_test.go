@@ -0,0 +1,28 @@
+package klvprom
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/StefanGrimminck/klvparser"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollectorReportsLatestValue(t *testing.T) {
+	c := NewCollector()
+	c.ObserveFrame(map[int]*klvparser.KLVTag{13: {Name: "Sensor Latitude", Value: 60.5}})
+	c.ObserveFrame(map[int]*klvparser.KLVTag{13: {Name: "Sensor Latitude", Value: 61.25}})
+	c.ObserveError(13, nil)
+	c.ObserveError(13, nil)
+	expected := `
+# HELP klv_tag_value Latest decoded value of a numeric MISB ST 0601 tag.
+# TYPE klv_tag_value gauge
+klv_tag_value{code="SENS_LAT",name="Sensor Latitude",tag="13"} 61.25
+# HELP klv_decode_failures_total Number of decode failures reported for a MISB ST 0601 tag; tag 0 covers whole packets.
+# TYPE klv_decode_failures_total counter
+klv_decode_failures_total{tag="13"} 2
+`
+	if err := testutil.CollectAndCompare(c, strings.NewReader(expected)); err != nil {
+		t.Fatal(err)
+	}
+}
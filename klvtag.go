@@ -1,6 +1,9 @@
 package klvparser
 
-import "math"
+import (
+	"math"
+	"math/big"
+)
 
 // KLVTag represents an individual KLV tag and its metadata.
 type KLVTag struct {
@@ -10,152 +13,258 @@ type KLVTag struct {
 	Length   int
 	Unit     string // Optional unit of measurement
 	Value    interface{}
+	Children map[int]*KLVTag // Decoded items of a nested local set, keyed by inner tag
+	Raw      []byte          // Copy of the value bytes as received, for decoding what the parser does not interpret
+
+	// OutOfRange is set when the tag was present but carried the reserved "out of
+	// range" indicator, the most negative value of a signed field. Value is nil then,
+	// as it is for a tag that could not be decoded.
+	OutOfRange bool
+
+	// Status tells whether the value was decoded, and if not, why.
+	Status TagStatus
+
+	// Uncertainty is the standard deviation of the value given by the packet's
+	// SDCC-FLP matrix (tag 102), in the tag's unit. It is set only when
+	// SetAttachUncertainty is enabled and the matrix covers the tag.
+	Uncertainty *float64
+
+	micros    uint64 // Exact microseconds since the epoch of a time tag, see PrecisionTime
+	hasMicros bool
+	rate      *big.Rat // Exact rate of the Sensor Frame Rate Pack, see FrameRate
+
+	location    Location // Position of a text or coordinate pack tag, see Location
+	hasLocation bool
+}
+
+// reset clears the decoded value of the tag, keeping its metadata.
+func (t *KLVTag) reset() {
+	t.Value = nil
+	t.Children = nil
+	t.Raw = nil
+	t.OutOfRange = false
+	t.Status = StatusOK
+	t.Uncertainty = nil
+	t.micros, t.hasMicros = 0, false
+	t.rate = nil
+	t.location, t.hasLocation = Location{}, false
+}
+
+// Child returns the decoded item of a nested local set with the given name.
+func (t *KLVTag) Child(name string) (*KLVTag, bool) {
+	for _, child := range t.Children {
+		if child.Name == name {
+			return child, true
+		}
+	}
+	return nil, false
+}
+
+// tagDefinition holds the static metadata of a tag.
+type tagDefinition struct {
+	name     string
+	minValue float64
+	maxValue float64
+	length   int
+	unit     string
+}
+
+// newTag creates a KLVTag without a value from the definition.
+func (d tagDefinition) newTag() *KLVTag {
+	return &KLVTag{
+		Name:     d.name,
+		MinValue: d.minValue,
+		MaxValue: d.maxValue,
+		Length:   d.length,
+		Unit:     d.unit,
+	}
+}
+
+// newTagTable creates a KLVTag for each definition.
+func newTagTable(definitions map[int]tagDefinition) map[int]*KLVTag {
+	tags := make(map[int]*KLVTag, len(definitions))
+	for id, definition := range definitions {
+		tags[id] = definition.newTag()
+	}
+	return tags
+}
+
+// clone returns a deep copy of the tag.
+func (t *KLVTag) clone() *KLVTag {
+	c := *t
+	if s, ok := t.Value.(*string); ok && s != nil {
+		value := *s
+		c.Value = &value
+	}
+	if t.Raw != nil {
+		c.Raw = append([]byte(nil), t.Raw...)
+	}
+	if t.Uncertainty != nil {
+		uncertainty := *t.Uncertainty
+		c.Uncertainty = &uncertainty
+	}
+	if t.Children != nil {
+		c.Children = make(map[int]*KLVTag, len(t.Children))
+		for id, child := range t.Children {
+			c.Children[id] = child.clone()
+		}
+	}
+	return &c
+}
+
+// unsupportedSetTags lists the nested local sets that are delivered as undecoded hex.
+var unsupportedSetTags = map[int]bool{
+	73: true, // RVT Local Set
+	95: true, // SAR Motion Imagery Local Set
+	97: true, // Range Image Local Set
+	98: true, // Geo-Registration Local Set
+	99: true, // Composite Imaging Local Set
 }
 
-// tagMeta contains metadata for each MISB ST 0601 KLV tag.
-// This map defines the name, range, length, unit of measurement, and value for each tag.
-var tagMeta = map[int]*KLVTag{
-	1:   {"Checksum", 0, 65535, 2, "None", nil},
-	2:   {"Precision Time Stamp", 0, float64(math.MaxUint64), 8, "µs", nil},
-	3:   {"Mission ID", 0, 0, 127, "None", nil},
-	4:   {"Platform Tail Number", 0, 0, 127, "None", nil},
-	5:   {"Platform Heading Angle", 0, 360, 2, "°", nil},
-	6:   {"Platform Pitch Angle", -20, 20, 2, "°", nil},
-	7:   {"Platform Roll Angle", -50, 50, 2, "°", nil},
-	8:   {"Platform True Airspeed", 0, 255, 1, "m/s", nil},
-	9:   {"Platform Indicated Airspeed", 0, 255, 1, "m/s", nil},
-	10:  {"Platform Designation", 0, 0, 127, "None", nil},
-	11:  {"Image Source Sensor", 0, 0, 127, "None", nil},
-	12:  {"Image Coordinate System", 0, 0, 127, "None", nil},
-	13:  {"Sensor Latitude", -90.0, 90.0, 4, "°", nil},
-	14:  {"Sensor Longitude", -180.0, 180.0, 4, "°", nil},
-	15:  {"Sensor True Altitude", -900.0, 19000.0, 2, "m", nil},
-	16:  {"Sensor Horizontal Field of View", 0.0, 180.0, 2, "°", nil},
-	17:  {"Sensor Vertical Field of View", 0.0, 180.0, 2, "°", nil},
-	18:  {"Sensor Relative Azimuth Angle", 0.0, 360.0, 4, "°", nil},
-	19:  {"Sensor Relative Elevation Angle", -180.0, 180.0, 4, "°", nil},
-	20:  {"Sensor Relative Roll Angle", 0.0, 360.0, 4, "°", nil},
-	21:  {"Slant Range", 0.0, 5000000.0, 4, "m", nil},
-	22:  {"Target Width", 0.0, 10000.0, 2, "m", nil},
-	23:  {"Frame Center Latitude", -90.0, 90.0, 4, "°", nil},
-	24:  {"Frame Center Longitude", -180.0, 180.0, 4, "°", nil},
-	25:  {"Frame Center Elevation", -900.0, 19000.0, 2, "m", nil},
-	26:  {"Offset Corner Latitude Point 1", -0.075, 0.075, 2, "°", nil},
-	27:  {"Offset Corner Longitude Point 1", -0.075, 0.075, 2, "°", nil},
-	28:  {"Offset Corner Latitude Point 2", -0.075, 0.075, 2, "°", nil},
-	29:  {"Offset Corner Longitude Point 2", -0.075, 0.075, 2, "°", nil},
-	30:  {"Offset Corner Latitude Point 3", -0.075, 0.075, 2, "°", nil},
-	31:  {"Offset Corner Longitude Point 3", -0.075, 0.075, 2, "°", nil},
-	32:  {"Offset Corner Latitude Point 4", -0.075, 0.075, 2, "°", nil},
-	33:  {"Offset Corner Longitude Point 4", -0.075, 0.075, 2, "°", nil},
-	34:  {"Icing Detected", 0, 255, 1, "None", nil},
-	35:  {"Wind Direction", 0.0, 360.0, 2, "°", nil},
-	36:  {"Wind Speed", 0.0, 255.0, 1, "m/s", nil},
-	37:  {"Static Pressure", 0.0, 5000.0, 2, "mbar", nil},
-	38:  {"Density Altitude", -900.0, 19000.0, 2, "m", nil},
-	39:  {"Outside Air Temperature", -128, 127, 1, "°C", nil},
-	40:  {"Target Location Latitude", -90.0, 90.0, 4, "°", nil},
-	41:  {"Target Location Longitude", -180.0, 180.0, 4, "°", nil},
-	42:  {"Target Location Elevation", -900.0, 19000.0, 2, "m", nil},
-	43:  {"Target Track Gate Width", 0, 255, 1, "m", nil},
-	44:  {"Target Track Gate Height", 0, 255, 1, "m", nil},
-	45:  {"Target Error Estimate CE90", 0.0, 4095.9375, 2, "m", nil},
-	46:  {"Target Error Estimate LE90", 0.0, 4095.9375, 2, "m", nil},
-	47:  {"Generic Flag Data 01", 0, 255, 1, "None", nil},
-	48:  {"Security Local Metadata Set", 0, 0, 0, "None", nil},
-	49:  {"Differential Pressure", 0.0, 5000.0, 2, "hPa", nil},
-	50:  {"Platform Angle of Attack", -20.0, 20.0, 2, "°", nil},
-	51:  {"Platform Vertical Speed", -180.0, 180.0, 2, "m/s", nil},
-	52:  {"Platform Sideslip Angle", -20.0, 20.0, 2, "°", nil},
-	53:  {"Airfield Barometric Pressure", 0.0, 5000.0, 2, "hPa", nil},
-	54:  {"Airfield Elevation", -900.0, 19000.0, 2, "m", nil},
-	55:  {"Relative Humidity", 0.0, 100.0, 1, "%", nil},
-	56:  {"Platform Ground Speed", 0, 255, 1, "m/s", nil},
-	57:  {"Ground Range", 0, float64(math.MaxUint64), 4, "m", nil},
-	58:  {"Platform Fuel Remaining", 0.0, 10000.0, 2, "kg", nil},
-	59:  {"Platform Call Sign", 0, 0, 127, "None", nil},
-	60:  {"Weapon Load", 0, 0, 0, "None", nil},
-	61:  {"Weapon Fired", 0, 0, 0, "None", nil},
-	62:  {"Laser PRF Code", 0, 65535, 2, "None", nil},
-	63:  {"Sensor Field of View Name", 0, 255, 1, "None", nil},
-	64:  {"Platform Magnetic Heading", 0.0, 360.0, 2, "°", nil},
-	65:  {"UAS Datalink LS Version Number", 0, 255, 1, "None", nil},
-	66:  {"Target Location Covariance Matrix", 0, 0, 0, "None", nil},
-	67:  {"Alternate Platform Latitude", -90.0, 90.0, 4, "°", nil},
-	68:  {"Alternate Platform Longitude", -180.0, 180.0, 4, "°", nil},
-	69:  {"Alternate Platform Altitude", -900.0, 19000.0, 2, "m", nil},
-	70:  {"Alternate Platform Name", 0, 0, 127, "None", nil},
-	71:  {"Alternate Platform Heading", 0.0, 360.0, 2, "°", nil},
-	72:  {"Event Start Time UTC", 0, float64(math.MaxUint64), 8, "µs", nil},
-	73:  {"RVT Local Set", 0, 0, 0, "None", nil},
-	74:  {"VMTI Data Set", 0, 0, 0, "None", nil},
-	75:  {"Sensor Ellipsoid Height", -900.0, 19000.0, 2, "m", nil},
-	76:  {"Alternate Platform Ellipsoid Height", -900.0, 19000.0, 2, "m", nil},
-	77:  {"Operational Mode", 0, 255, 1, "None", nil},
-	78:  {"Frame Center Height Above Ellipsoid", -900.0, 19000.0, 2, "m", nil},
-	79:  {"Sensor North Velocity", -327.67, 327.67, 2, "m/s", nil},
-	80:  {"Sensor East Velocity", -327.67, 327.67, 2, "m/s", nil},
-	81:  {"Image Horizon Pixel Pack", 0, 0, 0, "None", nil},
-	82:  {"Corner Latitude Point 1", -90.0, 90.0, 4, "°", nil},
-	83:  {"Corner Longitude Point 1", -180.0, 180.0, 4, "°", nil},
-	84:  {"Corner Latitude Point 2", -90.0, 90.0, 4, "°", nil},
-	85:  {"Corner Longitude Point 2", -180.0, 180.0, 4, "°", nil},
-	86:  {"Corner Latitude Point 3", -90.0, 90.0, 4, "°", nil},
-	87:  {"Corner Longitude Point 3", -180.0, 180.0, 4, "°", nil},
-	88:  {"Corner Latitude Point 4", -90.0, 90.0, 4, "°", nil},
-	89:  {"Corner Longitude Point 4", -180.0, 180.0, 4, "°", nil},
-	90:  {"Platform True Airspeed Rate", -327.67, 327.67, 2, "m/s", nil},
-	91:  {"Target Width Extended", 0, 1500000.0, 4, "m", nil},
-	92:  {"Platform Angle of Attack Rate", -327.67, 327.67, 2, "°", nil},
-	93:  {"Platform Sideslip Angle Rate", -327.67, 327.67, 2, "°", nil},
-	94:  {"MIIS Core Identifier", 0, 0, 0, "None", nil},
-	95:  {"SAR Motion Imagery Local Set", 0, 0, 0, "None", nil},
-	96:  {"Target Width Extended", 0, 1500000.0, 4, "m", nil},
-	97:  {"Range Image Local Set", 0, 0, 0, "None", nil},
-	98:  {"Geo-Registration Local Set", 0, 0, 0, "None", nil},
-	99:  {"Composite Imaging Local Set", 0, 0, 0, "None", nil},
-	100: {"Segment Local Set", 0, 0, 0, "None", nil},
-	101: {"Amend Local Set", 0, 0, 0, "None", nil},
-	102: {"SDCC-FLP", 0, 0, 0, "None", nil},
-	103: {"Density Altitude Extended", -900.0, 19000.0, 2, "m", nil},
-	104: {"Sensor Ellipsoid Height Extended", -900.0, 19000.0, 2, "m", nil},
-	105: {"Alternate Platform Ellipsoid Height Extended", -900.0, 19000.0, 2, "m", nil},
-	106: {"Stream Designator", 0, 0, 127, "None", nil},
-	107: {"Operational Base", 0, 0, 127, "None", nil},
-	108: {"Broadcast Source", 0, 0, 127, "None", nil},
-	109: {"Range To Recovery Location", 0, float64(math.MaxUint64), 4, "km", nil},
-	110: {"Time Airborne", 0, float64(math.MaxUint64), 4, "s", nil},
-	111: {"Propulsion Unit Speed", 0, float64(math.MaxUint64), 4, "RPM", nil},
-	112: {"Platform Course Angle", 0, 360.0, 2, "°", nil},
-	113: {"Altitude AGL", 0, float64(math.MaxUint64), 4, "m", nil},
-	114: {"Radar Altimeter", 0, float64(math.MaxUint64), 4, "m", nil},
-	115: {"Control Command", 0, 0, 0, "None", nil},
-	116: {"Control Command Verification List", 0, 0, 0, "None", nil},
-	117: {"Sensor Azimuth Rate", -327.67, 327.67, 2, "dps", nil},
-	118: {"Sensor Elevation Rate", -327.67, 327.67, 2, "dps", nil},
-	119: {"Sensor Roll Rate", -327.67, 327.67, 2, "dps", nil},
-	120: {"On-board MI Storage Percent Full", 0.0, 100.0, 1, "%", nil},
-	121: {"Active Wavelength List", 0, 0, 0, "None", nil},
-	122: {"Country Codes", 0, 0, 0, "None", nil},
-	123: {"Number of NAVSATs in View", 0, 255, 1, "count", nil},
-	124: {"Positioning Method Source", 0, 255, 1, "None", nil},
-	125: {"Platform Status", 0, 12, 1, "None", nil},
-	126: {"Sensor Control Mode", 0, 255, 1, "None", nil},
-	127: {"Sensor Frame Rate Pack", 0, 0, 0, "None", nil},
-	128: {"Wavelengths List", 0, 0, 0, "None", nil},
-	129: {"Target ID", 0, 0, 127, "None", nil},
-	130: {"Airbase Locations", 0, 0, 0, "None", nil},
-	131: {"Take-off Time", 0, float64(math.MaxUint64), 4, "µs", nil},
-	132: {"Transmission Frequency", 0, float64(math.MaxUint64), 4, "MHz", nil},
-	133: {"On-board MI Storage Capacity", 0, float64(math.MaxUint64), 4, "GB", nil},
-	134: {"Zoom Percentage", 0.0, 100.0, 2, "%", nil},
-	135: {"Communications Method", 0, 0, 127, "None", nil},
-	136: {"Leap Seconds", -128, 127, 1, "s", nil},
-	137: {"Correction Offset", -float64(math.MaxUint64), float64(math.MaxUint64), 8, "µs", nil},
-	138: {"Payload List", 0, 0, 0, "None", nil},
-	139: {"Active Payloads", 0, 0, 127, "None", nil},
-	140: {"Weapons Stores", 0, 0, 0, "None", nil},
-	141: {"Waypoint List", 0, 0, 0, "None", nil},
-	142: {"View Domain", 0, 0, 0, "None", nil},
-	143: {"Metadata Substream ID", 0, 0, 17, "None", nil},
+// tagDefinitions contains metadata for each MISB ST 0601 KLV tag.
+// This map defines the name, range, length, and unit of measurement for each tag.
+var tagDefinitions = map[int]tagDefinition{
+	1:   {"Checksum", 0, 65535, 2, "None"},
+	2:   {"Precision Time Stamp", 0, float64(math.MaxUint64), 8, "µs"},
+	3:   {"Mission ID", 0, 0, 127, "None"},
+	4:   {"Platform Tail Number", 0, 0, 127, "None"},
+	5:   {"Platform Heading Angle", 0, 360, 2, "°"},
+	6:   {"Platform Pitch Angle", -20, 20, 2, "°"},
+	7:   {"Platform Roll Angle", -50, 50, 2, "°"},
+	8:   {"Platform True Airspeed", 0, 255, 1, "m/s"},
+	9:   {"Platform Indicated Airspeed", 0, 255, 1, "m/s"},
+	10:  {"Platform Designation", 0, 0, 127, "None"},
+	11:  {"Image Source Sensor", 0, 0, 127, "None"},
+	12:  {"Image Coordinate System", 0, 0, 127, "None"},
+	13:  {"Sensor Latitude", -90.0, 90.0, 4, "°"},
+	14:  {"Sensor Longitude", -180.0, 180.0, 4, "°"},
+	15:  {"Sensor True Altitude", -900.0, 19000.0, 2, "m"},
+	16:  {"Sensor Horizontal Field of View", 0.0, 180.0, 2, "°"},
+	17:  {"Sensor Vertical Field of View", 0.0, 180.0, 2, "°"},
+	18:  {"Sensor Relative Azimuth Angle", 0.0, 360.0, 4, "°"},
+	19:  {"Sensor Relative Elevation Angle", -180.0, 180.0, 4, "°"},
+	20:  {"Sensor Relative Roll Angle", 0.0, 360.0, 4, "°"},
+	21:  {"Slant Range", 0.0, 5000000.0, 4, "m"},
+	22:  {"Target Width", 0.0, 10000.0, 2, "m"},
+	23:  {"Frame Center Latitude", -90.0, 90.0, 4, "°"},
+	24:  {"Frame Center Longitude", -180.0, 180.0, 4, "°"},
+	25:  {"Frame Center Elevation", -900.0, 19000.0, 2, "m"},
+	26:  {"Offset Corner Latitude Point 1", -0.075, 0.075, 2, "°"},
+	27:  {"Offset Corner Longitude Point 1", -0.075, 0.075, 2, "°"},
+	28:  {"Offset Corner Latitude Point 2", -0.075, 0.075, 2, "°"},
+	29:  {"Offset Corner Longitude Point 2", -0.075, 0.075, 2, "°"},
+	30:  {"Offset Corner Latitude Point 3", -0.075, 0.075, 2, "°"},
+	31:  {"Offset Corner Longitude Point 3", -0.075, 0.075, 2, "°"},
+	32:  {"Offset Corner Latitude Point 4", -0.075, 0.075, 2, "°"},
+	33:  {"Offset Corner Longitude Point 4", -0.075, 0.075, 2, "°"},
+	34:  {"Icing Detected", 0, 255, 1, "None"},
+	35:  {"Wind Direction", 0.0, 360.0, 2, "°"},
+	36:  {"Wind Speed", 0.0, 255.0, 1, "m/s"},
+	37:  {"Static Pressure", 0.0, 5000.0, 2, "hPa"},
+	38:  {"Density Altitude", -900.0, 19000.0, 2, "m"},
+	39:  {"Outside Air Temperature", -128, 127, 1, "°C"},
+	40:  {"Target Location Latitude", -90.0, 90.0, 4, "°"},
+	41:  {"Target Location Longitude", -180.0, 180.0, 4, "°"},
+	42:  {"Target Location Elevation", -900.0, 19000.0, 2, "m"},
+	43:  {"Target Track Gate Width", 0, 510, 1, "pixels"},
+	44:  {"Target Track Gate Height", 0, 510, 1, "pixels"},
+	45:  {"Target Error Estimate CE90", 0.0, 4095.9375, 2, "m"},
+	46:  {"Target Error Estimate LE90", 0.0, 4095.9375, 2, "m"},
+	47:  {"Generic Flag Data 01", 0, 255, 1, "None"},
+	48:  {"Security Local Metadata Set", 0, 0, 0, "None"},
+	49:  {"Differential Pressure", 0.0, 5000.0, 2, "hPa"},
+	50:  {"Platform Angle of Attack", -20.0, 20.0, 2, "°"},
+	51:  {"Platform Vertical Speed", -180.0, 180.0, 2, "m/s"},
+	52:  {"Platform Sideslip Angle", -20.0, 20.0, 2, "°"},
+	53:  {"Airfield Barometric Pressure", 0.0, 5000.0, 2, "hPa"},
+	54:  {"Airfield Elevation", -900.0, 19000.0, 2, "m"},
+	55:  {"Relative Humidity", 0.0, 100.0, 1, "%"},
+	56:  {"Platform Ground Speed", 0, 255, 1, "m/s"},
+	57:  {"Ground Range", 0.0, 5000000.0, 4, "m"},
+	58:  {"Platform Fuel Remaining", 0.0, 10000.0, 2, "kg"},
+	59:  {"Platform Call Sign", 0, 0, 127, "None"},
+	60:  {"Weapon Load", 0, 65535, 2, "None"},
+	61:  {"Weapon Fired", 0, 255, 1, "None"},
+	62:  {"Laser PRF Code", 0, 65535, 2, "None"},
+	63:  {"Sensor Field of View Name", 0, 8, 1, "None"},
+	64:  {"Platform Magnetic Heading", 0.0, 360.0, 2, "°"},
+	65:  {"UAS Datalink LS Version Number", 0, 255, 1, "None"},
+	66:  {"Target Location Covariance Matrix", 0, 0, 0, "None"},
+	67:  {"Alternate Platform Latitude", -90.0, 90.0, 4, "°"},
+	68:  {"Alternate Platform Longitude", -180.0, 180.0, 4, "°"},
+	69:  {"Alternate Platform Altitude", -900.0, 19000.0, 2, "m"},
+	70:  {"Alternate Platform Name", 0, 0, 127, "None"},
+	71:  {"Alternate Platform Heading", 0.0, 360.0, 2, "°"},
+	72:  {"Event Start Time UTC", 0, float64(math.MaxUint64), 8, "µs"},
+	73:  {"RVT Local Set", 0, 0, 0, "None"},
+	74:  {"VMTI Data Set", 0, 0, 0, "None"},
+	75:  {"Sensor Ellipsoid Height", -900.0, 19000.0, 2, "m"},
+	76:  {"Alternate Platform Ellipsoid Height", -900.0, 19000.0, 2, "m"},
+	77:  {"Operational Mode", 0, 255, 1, "None"},
+	78:  {"Frame Center Height Above Ellipsoid", -900.0, 19000.0, 2, "m"},
+	79:  {"Sensor North Velocity", -327.67, 327.67, 2, "m/s"},
+	80:  {"Sensor East Velocity", -327.67, 327.67, 2, "m/s"},
+	81:  {"Image Horizon Pixel Pack", 0, 0, 0, "None"},
+	82:  {"Corner Latitude Point 1", -90.0, 90.0, 4, "°"},
+	83:  {"Corner Longitude Point 1", -180.0, 180.0, 4, "°"},
+	84:  {"Corner Latitude Point 2", -90.0, 90.0, 4, "°"},
+	85:  {"Corner Longitude Point 2", -180.0, 180.0, 4, "°"},
+	86:  {"Corner Latitude Point 3", -90.0, 90.0, 4, "°"},
+	87:  {"Corner Longitude Point 3", -180.0, 180.0, 4, "°"},
+	88:  {"Corner Latitude Point 4", -90.0, 90.0, 4, "°"},
+	89:  {"Corner Longitude Point 4", -180.0, 180.0, 4, "°"},
+	90:  {"Platform Pitch Angle (Full)", -90.0, 90.0, 4, "°"},
+	91:  {"Platform Roll Angle (Full)", -90.0, 90.0, 4, "°"},
+	92:  {"Platform Angle of Attack (Full)", -90.0, 90.0, 4, "°"},
+	93:  {"Platform Sideslip Angle (Full)", -180.0, 180.0, 4, "°"},
+	94:  {"MIIS Core Identifier", 0, 0, 0, "None"},
+	95:  {"SAR Motion Imagery Local Set", 0, 0, 0, "None"},
+	96:  {"Target Width Extended", 0, 1500000.0, 3, "m"},
+	97:  {"Range Image Local Set", 0, 0, 0, "None"},
+	98:  {"Geo-Registration Local Set", 0, 0, 0, "None"},
+	99:  {"Composite Imaging Local Set", 0, 0, 0, "None"},
+	100: {"Segment Local Set", 0, 0, 0, "None"},
+	101: {"Amend Local Set", 0, 0, 0, "None"},
+	102: {"SDCC-FLP", 0, 0, 0, "None"},
+	103: {"Density Altitude Extended", -900.0, 40000.0, 3, "m"},
+	104: {"Sensor Ellipsoid Height Extended", -900.0, 40000.0, 3, "m"},
+	105: {"Alternate Platform Ellipsoid Height Extended", -900.0, 40000.0, 3, "m"},
+	106: {"Stream Designator", 0, 0, 127, "None"},
+	107: {"Operational Base", 0, 0, 127, "None"},
+	108: {"Broadcast Source", 0, 0, 127, "None"},
+	109: {"Range To Recovery Location", 0, 21000.0, 3, "km"},
+	110: {"Time Airborne", 0, float64(math.MaxUint64), 4, "s"},
+	111: {"Propulsion Unit Speed", 0, float64(math.MaxUint64), 4, "RPM"},
+	112: {"Platform Course Angle", 0, 360.0, 2, "°"},
+	113: {"Altitude AGL", -900.0, 40000.0, 3, "m"},
+	114: {"Radar Altimeter", -900.0, 40000.0, 3, "m"},
+	115: {"Control Command", 0, 0, 0, "None"},
+	116: {"Control Command Verification List", 0, 0, 0, "None"},
+	117: {"Sensor Azimuth Rate", -1000.0, 1000.0, 3, "dps"},
+	118: {"Sensor Elevation Rate", -1000.0, 1000.0, 3, "dps"},
+	119: {"Sensor Roll Rate", -1000.0, 1000.0, 3, "dps"},
+	120: {"On-board MI Storage Percent Full", 0.0, 100.0, 3, "%"},
+	121: {"Active Wavelength List", 0, 0, 0, "None"},
+	122: {"Country Codes", 0, 0, 0, "None"},
+	123: {"Number of NAVSATs in View", 0, 255, 1, "count"},
+	124: {"Positioning Method Source", 0, 255, 1, "None"},
+	125: {"Platform Status", 0, 12, 1, "None"},
+	126: {"Sensor Control Mode", 0, 255, 1, "None"},
+	127: {"Sensor Frame Rate Pack", 0, 0, 0, "fps"},
+	128: {"Wavelengths List", 0, 0, 0, "None"},
+	129: {"Target ID", 0, 0, 127, "None"},
+	130: {"Airbase Locations", 0, 0, 0, "None"},
+	131: {"Take-off Time", 0, float64(math.MaxUint64), 4, "µs"},
+	132: {"Transmission Frequency", 1.0, 99999.0, 3, "MHz"},
+	133: {"On-board MI Storage Capacity", 0, float64(math.MaxUint64), 4, "GB"},
+	134: {"Zoom Percentage", 0.0, 100.0, 3, "%"},
+	135: {"Communications Method", 0, 0, 127, "None"},
+	136: {"Leap Seconds", -128, 127, 1, "s"},
+	137: {"Correction Offset", -float64(math.MaxUint64), float64(math.MaxUint64), 8, "µs"},
+	138: {"Payload List", 0, 0, 0, "None"},
+	139: {"Active Payloads", 0, 0, 127, "None"},
+	140: {"Weapons Stores", 0, 0, 0, "None"},
+	141: {"Waypoint List", 0, 0, 0, "None"},
+	142: {"View Domain", 0, 0, 0, "None"},
+	143: {"Metadata Substream ID", 0, 0, 17, "None"},
 }
@@ -0,0 +1,120 @@
+package klvparser
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Location is a geodetic position decoded from a coordinate pack.
+type Location struct {
+	Latitude  float64 // Degrees
+	Longitude float64 // Degrees
+	Height    float64 // Meters, valid when HasHeight is set
+	HasHeight bool
+}
+
+// String formats the location as "lat,lon" or "lat,lon,height".
+func (l Location) String() string {
+	if l.HasHeight {
+		return fmt.Sprintf("%f,%f,%f", l.Latitude, l.Longitude, l.Height)
+	}
+	return fmt.Sprintf("%f,%f", l.Latitude, l.Longitude)
+}
+
+// extractLocation decodes a coordinate pack of a 4-byte latitude, a 4-byte longitude,
+// and an optional 2-byte height, scaled like tags 23, 24, and 25.
+func extractLocation(value []byte) *Location {
+	if len(value) != 8 && len(value) != 10 {
+		return nil
+	}
+	lat := extractLatitude(value[0:4])
+	lon := extractLongitude(value[4:8])
+	if lat == nil || lon == nil {
+		// A coordinate carrying the out of range indicator gives no usable location.
+		return nil
+	}
+	location := &Location{Latitude: *lat, Longitude: *lon}
+	if len(value) == 10 {
+		location.Height = *extractScaledUint16WithOffset(value[8:10], 19900.0/65535.0, -900.0)
+		location.HasHeight = true
+	}
+	return location
+}
+
+// Location returns the position carried by the Operational Base (tag 107) or
+// Broadcast Source (tag 108), decoded from a coordinate pack or from text of the
+// form "lat,lon" or "lat,lon,height". The tag's Value holds the text form of the
+// same position. ok is false for other tags and for text that is not a position.
+func (t *KLVTag) Location() (Location, bool) {
+	if t == nil || !t.hasLocation {
+		return Location{}, false
+	}
+	return t.location, true
+}
+
+// processTextOrLocation decodes a tag that carries either free text or a coordinate
+// pack, keeping both the text, as Value, and the position, for Location.
+func (p *KLVParser) processTextOrLocation(tag int, value []byte) {
+	meta := p.tagMeta[tag]
+	if meta == nil {
+		return
+	}
+	meta.Value, meta.location, meta.hasLocation = extractTextOrLocation(value)
+}
+
+// extractTextOrLocation decodes a tag that carries either free text or a coordinate
+// pack. Coordinate packs are returned with the text form of their position, and
+// text with the position it spells out, if any.
+func extractTextOrLocation(value []byte) (string, Location, bool) {
+	if !isPrintable(value) {
+		if location := extractLocation(value); location != nil {
+			return location.String(), *location, true
+		}
+	}
+	text := string(value)
+	location, ok := parseLocation(text)
+	return text, location, ok
+}
+
+// parseLocation parses a position written as "lat,lon" or "lat,lon,height", in
+// degrees and meters.
+func parseLocation(text string) (Location, bool) {
+	fields := strings.Split(text, ",")
+	if len(fields) != 2 && len(fields) != 3 {
+		return Location{}, false
+	}
+	var numbers [3]float64
+	for i, field := range fields {
+		number, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return Location{}, false
+		}
+		numbers[i] = number
+	}
+	if math.Abs(numbers[0]) > 90 || math.Abs(numbers[1]) > 180 {
+		return Location{}, false
+	}
+	location := Location{Latitude: numbers[0], Longitude: numbers[1]}
+	if len(fields) == 3 {
+		location.Height = numbers[2]
+		location.HasHeight = true
+	}
+	return location, true
+}
+
+// isPrintable reports whether value is valid UTF-8 made up of printable characters.
+func isPrintable(value []byte) bool {
+	if !utf8.Valid(value) {
+		return false
+	}
+	for _, r := range string(value) {
+		if !unicode.IsPrint(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package klvparser
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestTextOrLocation(t *testing.T) {
+	// 22.5° N, 45° E and 100 m, scaled like tags 23, 24 and 25.
+	pack := append(append(u32(0x20000000), u32(0x20000000)...), tagEncodings[25].encode(100)...)
+	tags := decodeOne(t, pkt(true, item(107, pack...), item(108, []byte("-33.5, 151.25")...)))
+
+	base, ok := tags[107].Location()
+	if !ok || math.Abs(base.Latitude-22.5) > 1e-6 || math.Abs(base.Longitude-45) > 1e-6 ||
+		!base.HasHeight || math.Abs(base.Height-100) > 0.5 {
+		t.Errorf("operational base = %+v, %v, want 22.5, 45 at 100 m", base, ok)
+	}
+	if text, _ := tags[107].Value.(string); text != base.String() {
+		t.Errorf("operational base text = %v, want %q", tags[107].Value, base.String())
+	}
+
+	source, ok := tags[108].Location()
+	if !ok || source.Latitude != -33.5 || source.Longitude != 151.25 || source.HasHeight {
+		t.Errorf("broadcast source = %+v, %v, want -33.5, 151.25", source, ok)
+	}
+	if tags[108].Value != "-33.5, 151.25" {
+		t.Errorf("broadcast source text = %v", tags[108].Value)
+	}
+
+	packet, err := Encode(tags)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(packet, item(107, pack...)) {
+		t.Errorf("encoded packet % x does not carry the coordinate pack", packet)
+	}
+}
+
+func TestTextWithoutLocation(t *testing.T) {
+	tag := decodeOne(t, pkt(true, item(107, []byte("Creech AFB")...)))[107]
+	if _, ok := tag.Location(); ok || tag.Value != "Creech AFB" {
+		t.Errorf("operational base = %v, want text without a location", tag.Value)
+	}
+}
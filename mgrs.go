@@ -0,0 +1,214 @@
+package klvparser
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// UTM and UPS projection parameters.
+const (
+	utmScaleFactor   = 0.9996
+	utmFalseEasting  = 500000.0
+	utmFalseNorthing = 10000000.0 // Southern hemisphere only
+	upsScaleFactor   = 0.994
+	upsFalseOrigin   = 2000000.0 // False easting and northing
+	mgrsSquareSize   = 100000.0
+)
+
+// mgrsBands lists the MGRS latitude bands of 8 degrees from 80°S; band X spans 72°N to 84°N.
+const mgrsBands = "CDEFGHJKLMNPQRSTUVWX"
+
+// ToMGRS converts a WGS 84 latitude and longitude in degrees to a Military Grid
+// Reference System string such as "18SUJ2348706483". precision is the number of
+// digits of each of the easting and northing, from 0 (the 100 km square) to 5
+// (1 m). Coordinates are truncated to the precision, as MGRS requires. The zone
+// number always has two digits. Latitudes from 80°S to 84°N use the UTM grid,
+// including the Norway and Svalbard zone exceptions, and the polar regions the
+// UPS grid, whose references start with A, B, Y or Z.
+func ToMGRS(lat, lon float64, precision int) (string, error) {
+	if precision < 0 || precision > 5 {
+		return "", fmt.Errorf("%w: MGRS precision %d, allowed 0 to 5", ErrInvalidCoordinate, precision)
+	}
+	if math.IsNaN(lat) || math.IsNaN(lon) || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return "", fmt.Errorf("%w: %f,%f", ErrInvalidCoordinate, lat, lon)
+	}
+	lon = wrapLongitude(lon)
+
+	var zone string
+	var column, row byte
+	var easting, northing float64
+	if lat < -80 || lat >= 84 {
+		zone, column, row, easting, northing = upsSquare(lat, lon)
+	} else {
+		zone, column, row, easting, northing = utmSquare(lat, lon)
+	}
+
+	var b strings.Builder
+	b.WriteString(zone)
+	b.WriteByte(column)
+	b.WriteByte(row)
+	if precision > 0 {
+		divisor := math.Pow(10, float64(5-precision))
+		e := int(math.Floor(math.Mod(easting, mgrsSquareSize) / divisor))
+		n := int(math.Floor(math.Mod(northing, mgrsSquareSize) / divisor))
+		fmt.Fprintf(&b, "%0*d%0*d", precision, e, precision, n)
+	}
+	return b.String(), nil
+}
+
+// MGRSPositions returns the MGRS references of the sensor (tags 13, 14) and the
+// frame center (tags 23, 24), at the precision of ToMGRS. The reference of a
+// position missing from tags is empty.
+func MGRSPositions(tags map[int]*KLVTag, precision int) (sensor, frameCenter string, err error) {
+	if position, ok := tagFloats(tags, 13, 14); ok {
+		if sensor, err = ToMGRS(position[0], position[1], precision); err != nil {
+			return "", "", err
+		}
+	}
+	if position, ok := tagFloats(tags, 23, 24); ok {
+		if frameCenter, err = ToMGRS(position[0], position[1], precision); err != nil {
+			return "", "", err
+		}
+	}
+	return sensor, frameCenter, nil
+}
+
+// utmZone returns the UTM zone of a position, applying the exceptions around
+// southwest Norway and Svalbard.
+func utmZone(lat, lon float64) int {
+	zone := int(math.Floor((lon+180)/6)) + 1
+	if zone > 60 {
+		zone = 60
+	}
+	switch {
+	case lat >= 56 && lat < 64 && lon >= 3 && lon < 12:
+		zone = 32
+	case lat >= 72 && lat < 84 && lon >= 0 && lon < 42:
+		switch {
+		case lon < 9:
+			zone = 31
+		case lon < 21:
+			zone = 33
+		case lon < 33:
+			zone = 35
+		default:
+			zone = 37
+		}
+	}
+	return zone
+}
+
+// utmSquare projects a position from 80°S to 84°N onto its UTM zone and returns
+// the grid zone designation, the letters of the 100 km square, and the easting
+// and northing in meters.
+func utmSquare(lat, lon float64) (zoneDesignation string, column, row byte, easting, northing float64) {
+	zone := utmZone(lat, lon)
+	band := int(math.Floor((lat + 80) / 8))
+	if band > len(mgrsBands)-1 {
+		band = len(mgrsBands) - 1
+	}
+	easting, northing = transverseMercator(lat, lon, float64((zone-1)*6-180+3))
+	if lat < 0 {
+		northing += utmFalseNorthing
+	}
+
+	// The column letters repeat every three zones and the row letters alternate
+	// between two offsets for odd and even zones.
+	columns := [3]string{"ABCDEFGH", "JKLMNPQR", "STUVWXYZ"}[(zone-1)%3]
+	const rows = "ABCDEFGHJKLMNPQRSTUV"
+	rowOffset := 0
+	if zone%2 == 0 {
+		rowOffset = 5
+	}
+	column = columns[(int(easting/mgrsSquareSize)-1+len(columns))%len(columns)]
+	row = rows[(int(northing/mgrsSquareSize)+rowOffset)%len(rows)]
+	return fmt.Sprintf("%02d%c", zone, mgrsBands[band]), column, row, easting, northing
+}
+
+// transverseMercator projects a WGS 84 position in degrees with the UTM scale
+// factor about the central meridian lon0, returning the easting with the UTM
+// false easting and the northing from the equator, in meters.
+func transverseMercator(lat, lon, lon0 float64) (easting, northing float64) {
+	const e2 = wgs84EccentricitySq
+	const ep2 = e2 / (1 - e2)
+	phi := lat * math.Pi / 180
+	sinPhi, cosPhi, tanPhi := math.Sin(phi), math.Cos(phi), math.Tan(phi)
+
+	n := wgs84SemiMajorAxis / math.Sqrt(1-e2*sinPhi*sinPhi)
+	t := tanPhi * tanPhi
+	c := ep2 * cosPhi * cosPhi
+	a := cosPhi * (lon - lon0) * math.Pi / 180
+	m := wgs84SemiMajorAxis * ((1-e2/4-3*e2*e2/64-5*e2*e2*e2/256)*phi -
+		(3*e2/8+3*e2*e2/32+45*e2*e2*e2/1024)*math.Sin(2*phi) +
+		(15*e2*e2/256+45*e2*e2*e2/1024)*math.Sin(4*phi) -
+		(35*e2*e2*e2/3072)*math.Sin(6*phi))
+
+	easting = utmScaleFactor*n*(a+(1-t+c)*math.Pow(a, 3)/6+
+		(5-18*t+t*t+72*c-58*ep2)*math.Pow(a, 5)/120) + utmFalseEasting
+	northing = utmScaleFactor * (m + n*tanPhi*(a*a/2+(5-t+9*c+4*c*c)*math.Pow(a, 4)/24+
+		(61-58*t+t*t+600*c-330*ep2)*math.Pow(a, 6)/720))
+	return easting, northing
+}
+
+// upsSquare projects a position north of 84°N or south of 80°S onto the UPS grid
+// and returns the polar zone letter, the letters of the 100 km square, and the
+// easting and northing in meters.
+func upsSquare(lat, lon float64) (zoneDesignation string, column, row byte, easting, northing float64) {
+	const e = 0.08181919084262149 // sqrt(wgs84EccentricitySq)
+	north := lat > 0
+	phi := math.Abs(lat) * math.Pi / 180
+	lambda := lon * math.Pi / 180
+	sinPhi := math.Sin(phi)
+	t := math.Tan(math.Pi/4-phi/2) / math.Pow((1-e*sinPhi)/(1+e*sinPhi), e/2)
+	rho := 2 * wgs84SemiMajorAxis * upsScaleFactor * t / math.Sqrt(math.Pow(1+e, 1+e)*math.Pow(1-e, 1-e))
+	easting = upsFalseOrigin + rho*math.Sin(lambda)
+	if north {
+		northing = upsFalseOrigin - rho*math.Cos(lambda)
+	} else {
+		northing = upsFalseOrigin + rho*math.Cos(lambda)
+	}
+
+	// The square letters of each polar zone start at a zone-specific letter and
+	// skip those MGRS leaves out, as tabulated in NGA's GEOTRANS.
+	var zone, firstColumn byte
+	var falseEasting, falseNorthing float64
+	switch {
+	case north && lon < 0:
+		zone, firstColumn, falseEasting, falseNorthing = 'Y', 'J', 800000, 1300000
+	case north:
+		zone, firstColumn, falseEasting, falseNorthing = 'Z', 'A', 2000000, 1300000
+	case lon < 0:
+		zone, firstColumn, falseEasting, falseNorthing = 'A', 'J', 800000, 800000
+	default:
+		zone, firstColumn, falseEasting, falseNorthing = 'B', 'A', 2000000, 800000
+	}
+
+	row = 'A' + byte((northing-falseNorthing)/mgrsSquareSize)
+	if row > 'H' {
+		row++ // Skip I
+	}
+	if row > 'N' {
+		row++ // Skip O
+	}
+	column = firstColumn + byte((easting-falseEasting)/mgrsSquareSize)
+	if easting < upsFalseOrigin {
+		if column > 'L' {
+			column += 3 // Skip M, N and O
+		}
+		if column > 'U' {
+			column += 2 // Skip V and W
+		}
+	} else {
+		if column > 'C' {
+			column += 2 // Skip D and E
+		}
+		if column > 'H' {
+			column++ // Skip I
+		}
+		if column > 'L' {
+			column += 3 // Skip M, N and O
+		}
+	}
+	return string(zone), column, row, easting, northing
+}
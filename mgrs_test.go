@@ -0,0 +1,55 @@
+package klvparser
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestToMGRS(t *testing.T) {
+	tests := []struct {
+		lat, lon  float64
+		precision int
+		want      string
+	}{
+		{38.8895, -77.0352, 5, "18SUJ2348606483"}, // Washington Monument
+		{38.8895, -77.0352, 0, "18SUJ"},
+		{-33.8568, 151.2153, 5, "56HLH3490052288"}, // Sydney Opera House
+		{0, 0, 5, "31NAA6602100000"},
+		{60, 5, 1, "32VKM75"},        // Norway exception
+		{78, 15, 0, "33XWG"},         // Svalbard exception
+		{90, 0, 5, "ZAH0000000000"},  // North Pole, UPS
+		{-90, 0, 5, "BAN0000000000"}, // South Pole, UPS
+	}
+	for _, tt := range tests {
+		got, err := ToMGRS(tt.lat, tt.lon, tt.precision)
+		if err != nil || got != tt.want {
+			t.Errorf("ToMGRS(%v, %v, %d) = %q, %v, want %q", tt.lat, tt.lon, tt.precision, got, err, tt.want)
+		}
+	}
+}
+
+func TestToMGRSInvalid(t *testing.T) {
+	for _, args := range [][3]float64{{91, 0, 5}, {0, 181, 5}, {0, 0, 6}, {0, 0, -1}} {
+		if _, err := ToMGRS(args[0], args[1], int(args[2])); !errors.Is(err, ErrInvalidCoordinate) {
+			t.Errorf("ToMGRS(%v, %v, %v) error = %v, want ErrInvalidCoordinate", args[0], args[1], args[2], err)
+		}
+	}
+}
+
+func TestUTMZoneSvalbard(t *testing.T) {
+	for lon, want := range map[float64]int{5: 31, 15: 33, 25: 35, 40: 37} {
+		if zone := utmZone(78, lon); zone != want {
+			t.Errorf("utmZone(78, %v) = %d, want %d", lon, zone, want)
+		}
+	}
+	if zone := utmZone(60, 5); zone != 32 {
+		t.Errorf("utmZone(60, 5) = %d, want 32", zone)
+	}
+}
+
+func TestMGRSPositions(t *testing.T) {
+	sensor, frameCenter, err := MGRSPositions(map[int]*KLVTag{13: {Value: 38.8895}, 14: {Value: -77.0352}}, 5)
+	if err != nil || sensor != "18SUJ2348606483" || frameCenter != "" {
+		t.Errorf("MGRSPositions = %q, %q, %v, want 18SUJ2348606483 and no frame center", sensor, frameCenter, err)
+	}
+}
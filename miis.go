@@ -0,0 +1,99 @@
+package klvparser
+
+import "fmt"
+
+// MIISCoreID is a decoded MISB ST 1204 MIIS Core Identifier (tag 94), which
+// identifies a motion imagery stream by the sensor and platform producing it.
+type MIISCoreID struct {
+	Version        int
+	Usage          byte    // Usage byte, telling which identifiers follow
+	SensorIDType   IDType  // How SensorID was assigned
+	PlatformIDType IDType  // How PlatformID was assigned
+	SensorID       *UUID   // nil when not carried
+	PlatformID     *UUID   // nil when not carried
+	WindowID       *UUID   // nil when not carried
+	MinorID        *UUID   // nil when not carried
+	CheckValue     *uint16 // Optional CRC-16 over the preceding bytes; nil when not carried
+}
+
+// IDType tells how an identifier of a MIIS Core Identifier was assigned.
+type IDType int
+
+// Identifier types of the MIIS Core Identifier usage byte.
+const (
+	IDTypeNone     IDType = iota // The identifier is not carried
+	IDTypeManaged                // Assigned by a managing authority
+	IDTypeVirtual                // Generated for a virtual sensor or platform
+	IDTypePhysical               // Derived from the physical device
+)
+
+// UUID is a 16-byte universally unique identifier.
+type UUID [16]byte
+
+// String formats the UUID in its canonical 8-4-4-4-12 hex form.
+func (u UUID) String() string {
+	return fmt.Sprintf("%X-%X-%X-%X-%X", u[0:4], u[4:6], u[6:8], u[8:10], u[10:16])
+}
+
+// Usage byte layout, from the most significant bit: a reserved bit, the 2-bit
+// sensor ID type, the 2-bit platform ID type, the window ID and minor ID
+// presence bits, and a reserved bit.
+const (
+	miisSensorTypeShift   = 5
+	miisPlatformTypeShift = 3
+	miisWindowIDFlag      = 1 << 2
+	miisMinorIDFlag       = 1 << 1
+)
+
+// extractMIISCoreID decodes a binary MIIS Core Identifier: the version, the usage
+// byte, the identifiers it announces in the order sensor, platform, window and
+// minor, and an optional 2-byte check value. It returns nil when the value does
+// not match this layout.
+func extractMIISCoreID(value []byte) *MIISCoreID {
+	if len(value) < 2 {
+		return nil
+	}
+	usage := value[1]
+	id := &MIISCoreID{
+		Version:        int(value[0]),
+		Usage:          usage,
+		SensorIDType:   IDType(usage >> miisSensorTypeShift & 0x03),
+		PlatformIDType: IDType(usage >> miisPlatformTypeShift & 0x03),
+	}
+	rest := value[2:]
+	next := func() *UUID {
+		if len(rest) < len(UUID{}) {
+			return nil
+		}
+		var u UUID
+		copy(u[:], rest)
+		rest = rest[len(u):]
+		return &u
+	}
+	present := []struct {
+		carried bool
+		dst     **UUID
+	}{
+		{id.SensorIDType != IDTypeNone, &id.SensorID},
+		{id.PlatformIDType != IDTypeNone, &id.PlatformID},
+		{usage&miisWindowIDFlag != 0, &id.WindowID},
+		{usage&miisMinorIDFlag != 0, &id.MinorID},
+	}
+	for _, field := range present {
+		if !field.carried {
+			continue
+		}
+		if *field.dst = next(); *field.dst == nil {
+			return nil
+		}
+	}
+	switch len(rest) {
+	case 0:
+	case 2:
+		check := uint16(rest[0])<<8 | uint16(rest[1])
+		id.CheckValue = &check
+	default:
+		return nil
+	}
+	return id
+}
@@ -0,0 +1,37 @@
+package klvparser
+
+import "testing"
+
+func TestMIISCoreID(t *testing.T) {
+	// Version 1, a physical sensor ID and a virtual platform ID, followed by a check value.
+	value := []byte{1, byte(IDTypePhysical)<<miisSensorTypeShift | byte(IDTypeVirtual)<<miisPlatformTypeShift}
+	for i := 0; i < 32; i++ {
+		value = append(value, byte(i))
+	}
+	value = append(value, 0xAB, 0xCD)
+	id, ok := decodeOne(t, pkt(true, item(94, value...)))[94].Value.(MIISCoreID)
+	if !ok {
+		t.Fatal("tag 94 is not a MIISCoreID")
+	}
+	if id.Version != 1 || id.SensorIDType != IDTypePhysical || id.PlatformIDType != IDTypeVirtual {
+		t.Errorf("core ID = %+v", id)
+	}
+	if id.SensorID == nil || id.SensorID.String() != "00010203-0405-0607-0809-0A0B0C0D0E0F" {
+		t.Errorf("sensor ID = %v", id.SensorID)
+	}
+	if id.PlatformID == nil || id.PlatformID[0] != 16 || id.PlatformID[15] != 31 {
+		t.Errorf("platform ID = %v", id.PlatformID)
+	}
+	if id.WindowID != nil || id.MinorID != nil {
+		t.Errorf("window and minor IDs = %v, %v, want none", id.WindowID, id.MinorID)
+	}
+	if id.CheckValue == nil || *id.CheckValue != 0xABCD {
+		t.Errorf("check value = %v, want 0xABCD", id.CheckValue)
+	}
+
+	// A sensor ID is announced but not carried.
+	truncated := decodeOne(t, pkt(true, item(94, 1, 0x60, 1)))[94].Value
+	if _, ok := truncated.(*string); !ok {
+		t.Errorf("truncated core ID = %#v, want a hex dump", truncated)
+	}
+}
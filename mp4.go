@@ -0,0 +1,374 @@
+package klvparser
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// mp4Box is a single ISO BMFF box with its payload.
+type mp4Box struct {
+	boxType string
+	data    []byte
+}
+
+// mp4Sample locates one sample of a track within the file.
+type mp4Sample struct {
+	offset int64
+	size   int64
+	pts    time.Duration
+}
+
+// ParseMP4 locates the timed-metadata track of an MP4/QuickTime file and feeds
+// each of its samples through the parser. onSample, if non-nil, is called with
+// the presentation time of every sample before the packets it contains are
+// delivered to the callback.
+func (p *KLVParser) ParseMP4(r io.ReadSeeker, onSample func(pts time.Duration)) error {
+	fileSize, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return fmt.Errorf("failed to seek to end of file: %w", err)
+	}
+	moov, err := findTopLevelBox(r, "moov")
+	if err != nil {
+		return err
+	}
+	samples, err := metadataTrackSamples(moov, fileSize)
+	if err != nil {
+		return err
+	}
+	for _, sample := range samples {
+		// Sizes come from the file, so check them against it before allocating.
+		if sample.offset < 0 || sample.size > fileSize-sample.offset {
+			return errors.New("sample extends past the end of the file")
+		}
+		if _, err := r.Seek(sample.offset, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to seek to sample: %w", err)
+		}
+		data := make([]byte, sample.size)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return fmt.Errorf("failed to read sample: %w", err)
+		}
+		if onSample != nil {
+			onSample(sample.pts)
+		}
+		if err := p.ProcessChunk(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findTopLevelBox scans r from the start for a top-level box and returns its payload.
+func findTopLevelBox(r io.ReadSeeker, boxType string) ([]byte, error) {
+	end, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := r.Seek(0, io.SeekStart)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, 16)
+	for {
+		if _, err := io.ReadFull(r, header[:8]); err != nil {
+			if err == io.EOF {
+				return nil, fmt.Errorf("no %q box found", boxType)
+			}
+			return nil, err
+		}
+		size := int64(binary.BigEndian.Uint32(header[0:4]))
+		headerSize := int64(8)
+		if size == 1 {
+			if _, err := io.ReadFull(r, header[8:16]); err != nil {
+				return nil, err
+			}
+			size = int64(binary.BigEndian.Uint64(header[8:16]))
+			headerSize = 16
+		}
+		if size == 0 {
+			size = end - offset
+		}
+		if size < headerSize || size > end-offset {
+			return nil, errors.New("invalid box size")
+		}
+		if string(header[4:8]) == boxType {
+			data := make([]byte, size-headerSize)
+			if _, err := io.ReadFull(r, data); err != nil {
+				return nil, err
+			}
+			return data, nil
+		}
+		offset += size
+		if _, err := r.Seek(offset, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// childBoxes splits the payload of a container box into its child boxes.
+func childBoxes(data []byte) ([]mp4Box, error) {
+	var boxes []mp4Box
+	for len(data) > 0 {
+		if len(data) < 8 {
+			return nil, errors.New("truncated box header")
+		}
+		size := uint64(binary.BigEndian.Uint32(data[0:4]))
+		headerSize := uint64(8)
+		switch size {
+		case 0:
+			size = uint64(len(data))
+		case 1:
+			if len(data) < 16 {
+				return nil, errors.New("truncated box header")
+			}
+			size = binary.BigEndian.Uint64(data[8:16])
+			headerSize = 16
+		}
+		if size < headerSize || size > uint64(len(data)) {
+			return nil, errors.New("invalid box size")
+		}
+		boxes = append(boxes, mp4Box{boxType: string(data[4:8]), data: data[headerSize:size]})
+		data = data[size:]
+	}
+	return boxes, nil
+}
+
+// findChildBox follows a path of box types below a container payload.
+func findChildBox(data []byte, path ...string) ([]byte, bool) {
+	for _, boxType := range path {
+		boxes, err := childBoxes(data)
+		if err != nil {
+			return nil, false
+		}
+		found := false
+		for _, box := range boxes {
+			if box.boxType == boxType {
+				data = box.data
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return data, true
+}
+
+// metadataTrackSamples returns the samples of the first track with a "meta" handler.
+// fileSize, the length of the file, bounds the number of samples.
+func metadataTrackSamples(moov []byte, fileSize int64) ([]mp4Sample, error) {
+	boxes, err := childBoxes(moov)
+	if err != nil {
+		return nil, err
+	}
+	for _, box := range boxes {
+		if box.boxType != "trak" {
+			continue
+		}
+		hdlr, ok := findChildBox(box.data, "mdia", "hdlr")
+		if !ok || len(hdlr) < 12 || string(hdlr[8:12]) != "meta" {
+			continue
+		}
+		return trackSamples(box.data, fileSize)
+	}
+	return nil, errors.New("no timed-metadata track found")
+}
+
+// trackSamples resolves the offset, size, and presentation time of every sample of a track.
+func trackSamples(trak []byte, fileSize int64) ([]mp4Sample, error) {
+	mdhd, ok := findChildBox(trak, "mdia", "mdhd")
+	if !ok {
+		return nil, errors.New("missing mdhd box")
+	}
+	timescale, err := mediaTimescale(mdhd)
+	if err != nil {
+		return nil, err
+	}
+	stbl, ok := findChildBox(trak, "mdia", "minf", "stbl")
+	if !ok {
+		return nil, errors.New("missing stbl box")
+	}
+
+	sizes, err := sampleSizes(stbl, fileSize)
+	if err != nil {
+		return nil, err
+	}
+	offsets, err := sampleOffsets(stbl, sizes)
+	if err != nil {
+		return nil, err
+	}
+	times, err := sampleTimes(stbl, len(sizes))
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]mp4Sample, len(sizes))
+	for i := range samples {
+		samples[i] = mp4Sample{
+			offset: offsets[i],
+			size:   sizes[i],
+			pts:    ticksToDuration(times[i], timescale),
+		}
+	}
+	return samples, nil
+}
+
+// mediaTimescale reads the timescale from an mdhd payload.
+func mediaTimescale(mdhd []byte) (int64, error) {
+	var timescale uint32
+	switch {
+	case len(mdhd) >= 24 && mdhd[0] == 1:
+		timescale = binary.BigEndian.Uint32(mdhd[20:24])
+	case len(mdhd) >= 16 && mdhd[0] == 0:
+		timescale = binary.BigEndian.Uint32(mdhd[12:16])
+	default:
+		return 0, errors.New("invalid mdhd box")
+	}
+	if timescale == 0 {
+		return 0, errors.New("invalid media timescale")
+	}
+	return int64(timescale), nil
+}
+
+// sampleSizes reads the size of every sample from the stsz box. A box with a
+// uniform size lists no sizes, so its sample count is bounded by how many
+// samples of that size fit in a file of fileSize bytes instead.
+func sampleSizes(stbl []byte, fileSize int64) ([]int64, error) {
+	stsz, ok := findChildBox(stbl, "stsz")
+	if !ok || len(stsz) < 12 {
+		return nil, errors.New("missing stsz box")
+	}
+	uniformSize := binary.BigEndian.Uint32(stsz[4:8])
+	count := int64(binary.BigEndian.Uint32(stsz[8:12]))
+	if uniformSize == 0 && int64(len(stsz)) < 12+4*count {
+		return nil, errors.New("truncated stsz box")
+	}
+	if uniformSize != 0 && count > fileSize/int64(uniformSize) {
+		return nil, errors.New("stsz box lists more samples than the file holds")
+	}
+	sizes := make([]int64, count)
+	for i := range sizes {
+		if uniformSize != 0 {
+			sizes[i] = int64(uniformSize)
+		} else {
+			sizes[i] = int64(binary.BigEndian.Uint32(stsz[12+4*i:]))
+		}
+	}
+	return sizes, nil
+}
+
+// sampleOffsets resolves the file offset of every sample from the stsc and stco/co64 boxes.
+func sampleOffsets(stbl []byte, sizes []int64) ([]int64, error) {
+	var chunkOffsets []int64
+	if stco, ok := findChildBox(stbl, "stco"); ok && len(stco) >= 8 {
+		count := int(binary.BigEndian.Uint32(stco[4:8]))
+		if len(stco) < 8+4*count {
+			return nil, errors.New("truncated stco box")
+		}
+		for i := 0; i < count; i++ {
+			chunkOffsets = append(chunkOffsets, int64(binary.BigEndian.Uint32(stco[8+4*i:])))
+		}
+	} else if co64, ok := findChildBox(stbl, "co64"); ok && len(co64) >= 8 {
+		count := int(binary.BigEndian.Uint32(co64[4:8]))
+		if len(co64) < 8+8*count {
+			return nil, errors.New("truncated co64 box")
+		}
+		for i := 0; i < count; i++ {
+			chunkOffsets = append(chunkOffsets, int64(binary.BigEndian.Uint64(co64[8+8*i:])))
+		}
+	} else {
+		return nil, errors.New("missing chunk offset box")
+	}
+
+	stsc, ok := findChildBox(stbl, "stsc")
+	if !ok || len(stsc) < 8 {
+		return nil, errors.New("missing stsc box")
+	}
+	entries := int(binary.BigEndian.Uint32(stsc[4:8]))
+	if len(stsc) < 8+12*entries {
+		return nil, errors.New("truncated stsc box")
+	}
+
+	offsets := make([]int64, 0, len(sizes))
+	sample := 0
+	for entry := 0; entry < entries && sample < len(sizes); entry++ {
+		firstChunk := int(binary.BigEndian.Uint32(stsc[8+12*entry:]))
+		samplesPerChunk := int(binary.BigEndian.Uint32(stsc[12+12*entry:]))
+		lastChunk := len(chunkOffsets)
+		if entry+1 < entries {
+			lastChunk = int(binary.BigEndian.Uint32(stsc[8+12*(entry+1):])) - 1
+		}
+		for chunk := firstChunk; chunk <= lastChunk && sample < len(sizes); chunk++ {
+			if chunk < 1 || chunk > len(chunkOffsets) {
+				return nil, errors.New("invalid chunk index in stsc box")
+			}
+			offset := chunkOffsets[chunk-1]
+			for i := 0; i < samplesPerChunk && sample < len(sizes); i++ {
+				offsets = append(offsets, offset)
+				offset += sizes[sample]
+				sample++
+			}
+		}
+	}
+	if len(offsets) != len(sizes) {
+		return nil, errors.New("sample table does not cover all samples")
+	}
+	return offsets, nil
+}
+
+// sampleTimes returns the presentation time of every sample in media ticks,
+// from the stts box and the optional ctts box.
+func sampleTimes(stbl []byte, count int) ([]int64, error) {
+	stts, ok := findChildBox(stbl, "stts")
+	if !ok || len(stts) < 8 {
+		return nil, errors.New("missing stts box")
+	}
+	entries := int(binary.BigEndian.Uint32(stts[4:8]))
+	if len(stts) < 8+8*entries {
+		return nil, errors.New("truncated stts box")
+	}
+	times := make([]int64, 0, count)
+	var decodeTime int64
+	for entry := 0; entry < entries && len(times) < count; entry++ {
+		sampleCount := int(binary.BigEndian.Uint32(stts[8+8*entry:]))
+		delta := int64(binary.BigEndian.Uint32(stts[12+8*entry:]))
+		for i := 0; i < sampleCount && len(times) < count; i++ {
+			times = append(times, decodeTime)
+			decodeTime += delta
+		}
+	}
+	if len(times) != count {
+		return nil, errors.New("stts box does not cover all samples")
+	}
+
+	if ctts, ok := findChildBox(stbl, "ctts"); ok && len(ctts) >= 8 {
+		entries := int(binary.BigEndian.Uint32(ctts[4:8]))
+		if len(ctts) < 8+8*entries {
+			return nil, errors.New("truncated ctts box")
+		}
+		sample := 0
+		for entry := 0; entry < entries && sample < count; entry++ {
+			sampleCount := int(binary.BigEndian.Uint32(ctts[8+8*entry:]))
+			raw := binary.BigEndian.Uint32(ctts[12+8*entry:])
+			offset := int64(raw)
+			if ctts[0] == 1 {
+				offset = int64(int32(raw))
+			}
+			for i := 0; i < sampleCount && sample < count; i++ {
+				times[sample] += offset
+				sample++
+			}
+		}
+	}
+	return times, nil
+}
+
+// ticksToDuration converts a time in media ticks to a duration without overflowing.
+func ticksToDuration(ticks, timescale int64) time.Duration {
+	seconds := ticks / timescale
+	remainder := ticks % timescale
+	return time.Duration(seconds)*time.Second + time.Duration(remainder)*time.Second/time.Duration(timescale)
+}
@@ -0,0 +1,90 @@
+package klvparser
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+)
+
+// box encodes an ISO BMFF box of type boxType holding the concatenated payloads.
+func box(boxType string, payloads ...[]byte) []byte {
+	body := bytes.Join(payloads, nil)
+	return append(append(u32(uint32(8+len(body))), boxType...), body...)
+}
+
+// fullBox encodes the payload of a full box: version and flags, then fields.
+func fullBox(versionFlags uint32, fields ...[]byte) []byte {
+	return append(u32(versionFlags), bytes.Join(fields, nil)...)
+}
+
+// metadataMP4 builds a file with one timed-metadata track holding samples in a
+// single chunk, 500 ms apart, and an stsz box of the given uniform size and count.
+func metadataMP4(samples [][]byte, stsz []byte) []byte {
+	ftyp := box("ftyp", []byte("isom"), u32(0))
+	mdat := box("mdat", samples...)
+	stbl := box("stbl",
+		box("stsd", fullBox(0, u32(0))),
+		box("stts", fullBox(0, u32(1), u32(uint32(len(samples))), u32(500))),
+		box("stsz", stsz),
+		box("stsc", fullBox(0, u32(1), u32(1), u32(uint32(len(samples))), u32(1))),
+		box("stco", fullBox(0, u32(1), u32(uint32(len(ftyp)+8)))),
+	)
+	trak := box("trak", box("mdia",
+		box("mdhd", fullBox(0, u32(0), u32(0), u32(1000), u32(1000), u32(0))),
+		box("hdlr", fullBox(0, u32(0), []byte("meta"), make([]byte, 13))),
+		box("minf", stbl)))
+	file := append(ftyp, mdat...)
+	return append(file, box("moov", trak)...)
+}
+
+func TestParseMP4(t *testing.T) {
+	first := pkt(true, item(5, u16(100)...))
+	second := pkt(true, item(5, u16(200)...))
+	stsz := fullBox(0, u32(0), u32(2), u32(uint32(len(first))), u32(uint32(len(second))))
+	file := metadataMP4([][]byte{first, second}, stsz)
+
+	var current time.Duration
+	var times []time.Duration
+	var headings []float64
+	p := NewKLVParser(func(tags map[int]*KLVTag) {
+		times = append(times, current)
+		headings = append(headings, tags[5].Value.(float64))
+	})
+	p.Logger = nil
+	if err := p.ParseMP4(bytes.NewReader(file), func(pts time.Duration) { current = pts }); err != nil {
+		t.Fatal(err)
+	}
+	if len(times) != 2 {
+		t.Fatalf("decoded %d packets, want 2", len(times))
+	}
+	if times[0] != 0 || times[1] != 500*time.Millisecond {
+		t.Errorf("times = %v, want [0s 500ms]", times)
+	}
+	for i, raw := range []float64{100, 200} {
+		if want := raw * 360 / 65535; math.Abs(headings[i]-want) > 1e-9 {
+			t.Errorf("heading of sample %d = %v, want %v", i, headings[i], want)
+		}
+	}
+}
+
+func TestParseMP4RejectsOversizedCounts(t *testing.T) {
+	sample := pkt(true, item(5, u16(100)...))
+	tests := []struct {
+		name string
+		file []byte
+	}{
+		{"uniform sample count", metadataMP4([][]byte{sample}, fullBox(0, u32(1), u32(0xFFFFFFFF)))},
+		{"sample size", metadataMP4([][]byte{sample}, fullBox(0, u32(0), u32(1), u32(0xFFFFFFF0)))},
+		{"box size", append(u32(0xFFFFFFF0), "moov"...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewKLVParser(func(map[int]*KLVTag) {})
+			p.Logger = nil
+			if err := p.ParseMP4(bytes.NewReader(tt.file), nil); err == nil {
+				t.Fatal("ParseMP4 accepted a size larger than the file")
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package klvparser
+
+// nestedTag describes an item of a nested local set and how its value is decoded.
+type nestedTag struct {
+	definition tagDefinition
+	decode     func([]byte) interface{}
+}
+
+// securityTags describes the MISB ST 0102 Security Metadata local set carried in tag 48.
+var securityTags = map[int]nestedTag{
+	1:  {tagDefinition{"Security Classification", 1, 5, 1, "None"}, decodeUint8},
+	2:  {tagDefinition{"Country Coding Method", 1, 255, 1, "None"}, decodeUint8},
+	3:  {tagDefinition{"Classifying Country", 0, 0, 6, "None"}, decodeString},
+	4:  {tagDefinition{"Security-SCI/SHI Information", 0, 0, 40, "None"}, decodeString},
+	5:  {tagDefinition{"Caveats", 0, 0, 32, "None"}, decodeString},
+	6:  {tagDefinition{"Releasing Instructions", 0, 0, 40, "None"}, decodeString},
+	7:  {tagDefinition{"Classified By", 0, 0, 40, "None"}, decodeString},
+	8:  {tagDefinition{"Derived From", 0, 0, 40, "None"}, decodeString},
+	9:  {tagDefinition{"Classification Reason", 0, 0, 48, "None"}, decodeString},
+	10: {tagDefinition{"Declassification Date", 0, 0, 8, "None"}, decodeString},
+	11: {tagDefinition{"Classification and Marking System", 0, 0, 40, "None"}, decodeString},
+	12: {tagDefinition{"Object Country Coding Method", 1, 255, 1, "None"}, decodeUint8},
+	14: {tagDefinition{"Classification Comments", 0, 0, 480, "None"}, decodeString},
+	16: {tagDefinition{"Stream ID", 0, 255, 1, "None"}, decodeUint8},
+	17: {tagDefinition{"Transport Stream ID", 0, 65535, 2, "None"}, decodeUint16},
+	22: {tagDefinition{"Version", 0, 65535, 2, "None"}, decodeUint16},
+}
+
+// nestedSets maps each MISB ST 0601 tag that carries a decoded local set to the
+// descriptions of its items.
+var nestedSets = map[int]map[int]nestedTag{
+	48: securityTags,
+}
+
+// processNestedSet stores a nested local set's hex dump as the tag value and its
+// decoded items as the tag's children. Items that could not be decoded can still
+// be reprocessed from the tag's raw value.
+func (p *KLVParser) processNestedSet(tag int, value []byte) {
+	meta := p.tagMeta[tag]
+	if meta == nil {
+		return
+	}
+	meta.Value = extractHex(value)
+	meta.Children = p.decodeNestedSet(nestedSets[tag], value)
+}
+
+// decodeNestedSet decodes the known items of a nested local set. Unknown items are skipped.
+func (p *KLVParser) decodeNestedSet(tags map[int]nestedTag, value []byte) map[int]*KLVTag {
+	children := make(map[int]*KLVTag)
+	for _, item := range p.splitLocalSet(value, localKeyLength) {
+		inner, ok := tags[item.key]
+		if !ok {
+			continue
+		}
+		child := inner.definition.newTag()
+		child.Value = inner.decode(item.value)
+		children[item.key] = child
+	}
+	return children
+}
+
+// decodeEmbeddedSet decodes a nested local set whose items are MISB ST 0601 tags,
+// such as the Amend and Segment Local Sets, with the regular tag decoders. It
+// uses a separate tag table, so the values of the packet carrying the set are
+// left alone. The checksum and a nested set of the same kind are skipped, as are
+// items that could not be decoded.
+func (p *KLVParser) decodeEmbeddedSet(tag int, value []byte) map[int]*KLVTag {
+	sub := NewKLVParser(nil)
+	sub.OnError = p.OnError
+	sub.Logger = p.Logger
+	sub.Units = p.Units
+	sub.lenientTimestamps = p.lenientTimestamps
+	for id, handler := range p.handlers {
+		sub.RegisterTag(id, p.tagMeta[id].Name, handler)
+	}
+	children := make(map[int]*KLVTag)
+	for _, item := range p.splitLocalSet(value, localKeyLength) {
+		if item.key == fillerTag || item.key == 1 || item.key == tag {
+			continue
+		}
+		sub.processTag(uint8(item.key), item.value)
+		if child := sub.tagMeta[item.key]; child != nil && child.Value != nil {
+			child.Raw = append([]byte(nil), item.value...)
+			children[item.key] = child
+		}
+	}
+	return children
+}
+
+// decodeUint8 decodes a single unsigned byte as a float64, or nil if the value is empty.
+func decodeUint8(value []byte) interface{} {
+	if uintVal := extractUint8(value); uintVal != nil {
+		return float64(*uintVal)
+	}
+	return nil
+}
+
+// decodeUint16 decodes a big-endian unsigned 16-bit value as a float64, or nil if the value is too short.
+func decodeUint16(value []byte) interface{} {
+	if uintVal := extractUint16(value); uintVal != nil {
+		return float64(*uintVal)
+	}
+	return nil
+}
+
+// decodeString decodes a value as a string.
+func decodeString(value []byte) interface{} {
+	return string(value)
+}
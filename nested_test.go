@@ -0,0 +1,58 @@
+package klvparser
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSecuritySetChildByName(t *testing.T) {
+	set := append(append(item(1, 3), item(2, 7)...), item(3, []byte("//USA")...)...)
+	security := decodeOne(t, pkt(true, item(48, set...)))[48]
+	classification, ok := security.Child("Security Classification")
+	if !ok || classification.Value != 3.0 {
+		t.Fatalf("Security Classification = %+v, %v", classification, ok)
+	}
+	if country, ok := security.Child("Classifying Country"); !ok || country.Value != "//USA" {
+		t.Errorf("Classifying Country = %+v, %v", country, ok)
+	}
+	if _, ok := security.Child("Caveats"); ok {
+		t.Error("found Caveats, which the set does not carry")
+	}
+}
+
+func TestNestedSetKeepsRawWithChildren(t *testing.T) {
+	// Inner tag 13 is not defined by MISB ST 0102.
+	set := append(append(item(1, 2), item(13, 0xAA, 0xBB)...), item(3, []byte("//GBR")...)...)
+	security := decodeOne(t, pkt(true, item(48, set...)))[48]
+	if !bytes.Equal(security.Raw, set) {
+		t.Errorf("Raw = % x, want % x", security.Raw, set)
+	}
+	if len(security.Children) != 2 || security.Children[1] == nil || security.Children[3] == nil {
+		t.Fatalf("children = %v, want items 1 and 3", security.Children)
+	}
+	if security.Children[3].Value != "//GBR" {
+		t.Errorf("Classifying Country = %v, want //GBR", security.Children[3].Value)
+	}
+}
+
+func TestNestedSetKeyLength(t *testing.T) {
+	set := append(append(item(1, 3), item(2, 7)...), item(4, 'U', 'S')...)
+	p := NewKLVParser(nil)
+	var keys []int
+	for _, entry := range p.splitLocalSet(set, localKeyLength) {
+		keys = append(keys, entry.key)
+	}
+	if len(keys) != 3 || keys[0] != 1 || keys[1] != 2 || keys[2] != 4 {
+		t.Errorf("keys with 1-byte local keys = %v, want [1 2 4]", keys)
+	}
+
+	// The packet itself is found by its 16-byte UL and its nested set split with 1-byte keys.
+	packet := pkt(true, item(48, set...))
+	if !bytes.Equal(packet[:universalKeyLength], MISB0601UL) {
+		t.Fatalf("packet key = % x, want the ST 0601 UL", packet[:universalKeyLength])
+	}
+	security := decodeOne(t, packet)[48]
+	if len(security.Children) != 3 || security.Children[1].Value != 3.0 || security.Children[2].Value != 7.0 {
+		t.Errorf("children = %v, want items 1, 2 and 4", security.Children)
+	}
+}
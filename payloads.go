@@ -0,0 +1,84 @@
+package klvparser
+
+// Payload is an entry of the Payload List (tag 138).
+type Payload struct {
+	ID   int    // Identifier, the payload's index in Active Payloads (tag 139)
+	Type int    // Payload type: 0 electro-optical, 1 LIDAR, 2 RADAR, 3 SIGINT, 4 SAR, 5 weapon
+	Name string // Name of the payload; empty when not known
+}
+
+// extractPayloadList decodes a Payload List (tag 138): a BER-OID payload count
+// followed by one record per payload, each a BER length and then the BER-OID
+// identifier, the BER-OID type, and the name filling the rest of the record.
+// It returns nil when the value does not match this layout.
+func (p *KLVParser) extractPayloadList(value []byte) []Payload {
+	count, n := extractBEROID(value)
+	if n == 0 || count > uint64(len(value)) {
+		return nil
+	}
+	index := n
+	payloads := make([]Payload, 0, count)
+	for uint64(len(payloads)) < count {
+		_, record, newIndex := p.extractTagValue(value, index)
+		if record == nil {
+			return nil
+		}
+		index = newIndex
+		id, n := extractBEROID(record)
+		if n == 0 {
+			return nil
+		}
+		record = record[n:]
+		payloadType, n := extractBEROID(record)
+		if n == 0 {
+			return nil
+		}
+		payloads = append(payloads, Payload{ID: int(id), Type: int(payloadType), Name: string(record[n:])})
+	}
+	return payloads
+}
+
+// extractActivePayloads decodes Active Payloads (tag 139), a bit array in which
+// bit i, counted from the least significant bit of the last byte, is set when
+// the payload with identifier i is active. It returns the active identifiers in
+// ascending order.
+func extractActivePayloads(value []byte) []int {
+	active := []int{}
+	for i := 0; i < 8*len(value); i++ {
+		if value[len(value)-1-i/8]&(1<<(i%8)) != 0 {
+			active = append(active, i)
+		}
+	}
+	return active
+}
+
+// ActivePayloads returns the payloads marked active in Active Payloads (tag 139),
+// completed with their type and name from the Payload List (tag 138) when the
+// frame carries it.
+func ActivePayloads(tags map[int]*KLVTag) ([]Payload, bool) {
+	data, ok := tags[139]
+	if !ok || data == nil {
+		return nil, false
+	}
+	ids, ok := data.Value.([]int)
+	if !ok {
+		return nil, false
+	}
+	known := make(map[int]Payload)
+	if list, ok := tags[138]; ok && list != nil {
+		if payloads, ok := list.Value.([]Payload); ok {
+			for _, payload := range payloads {
+				known[payload.ID] = payload
+			}
+		}
+	}
+	active := make([]Payload, len(ids))
+	for i, id := range ids {
+		payload, ok := known[id]
+		if !ok {
+			payload = Payload{ID: id}
+		}
+		active[i] = payload
+	}
+	return active, true
+}
@@ -0,0 +1,25 @@
+package klvparser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestActivePayloads(t *testing.T) {
+	list := []byte{2, 4, 0, 0, 'E', 'O', 7, 2, 1, 'L', 'I', 'D', 'A', 'R'}
+	// Bits 0 and 2 of the last byte and bit 0 of the first: payloads 0, 2 and 8.
+	tags := decodeOne(t, pkt(true, item(138, list...), item(139, 0x01, 0x05)))
+	if ids := tags[139].Value; !reflect.DeepEqual(ids, []int{0, 2, 8}) {
+		t.Errorf("active payload IDs = %v, want [0 2 8]", ids)
+	}
+	active, ok := ActivePayloads(tags)
+	want := []Payload{{ID: 0, Type: 0, Name: "EO"}, {ID: 2, Type: 1, Name: "LIDAR"}, {ID: 8}}
+	if !ok || !reflect.DeepEqual(active, want) {
+		t.Errorf("ActivePayloads = %+v, %v, want %+v", active, ok, want)
+	}
+
+	delete(tags, 139)
+	if _, ok := ActivePayloads(tags); ok {
+		t.Error("ActivePayloads without tag 139 is ok")
+	}
+}
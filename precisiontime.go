@@ -0,0 +1,44 @@
+package klvparser
+
+import "time"
+
+// PrecisionTime returns the exact time carried by a time tag: the Precision Time
+// Stamp (tag 2), Event Start Time (tag 72), or Take-off Time (tag 131). Unlike the
+// float64 Value, it keeps every microsecond. ok is false for other tags and for
+// time tags that could not be decoded.
+func (t *KLVTag) PrecisionTime() (time.Time, bool) {
+	if t == nil || !t.hasMicros {
+		return time.Time{}, false
+	}
+	return time.UnixMicro(int64(t.micros)).UTC(), true
+}
+
+// processTime decodes a count of microseconds since the epoch, keeping the exact
+// count for PrecisionTime alongside the float64 value.
+func (p *KLVParser) processTime(tag int, value []byte, extractor func([]byte) *uint64) {
+	meta := p.tagMeta[tag]
+	if meta == nil {
+		return
+	}
+	meta.hasMicros = false
+	p.processValue(tag, value, func(val []byte) *float64 {
+		micros := extractor(val)
+		if micros == nil {
+			return nil
+		}
+		meta.micros, meta.hasMicros = *micros, true
+		convertedVal := float64(*micros)
+		return &convertedVal
+	})
+}
+
+// extractSecondsAsMicros decodes whole seconds since the epoch, as sent by
+// non-conformant encoders in a 4-byte Precision Time Stamp, as microseconds.
+func extractSecondsAsMicros(value []byte) *uint64 {
+	seconds := extractUint32(value)
+	if seconds == nil {
+		return nil
+	}
+	micros := uint64(*seconds) * 1000000
+	return &micros
+}
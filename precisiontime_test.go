@@ -0,0 +1,50 @@
+package klvparser
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPrecisionTimeClearedByTruncatedTimestamp(t *testing.T) {
+	var got map[int]*KLVTag
+	p := NewKLVParser(func(tags map[int]*KLVTag) { got = tags })
+	p.Logger = nil
+	stamp := time.Date(2024, 5, 1, 12, 0, 0, 123456000, time.UTC)
+	if err := p.ProcessChunk(pkt(true, item(2, u64(uint64(stamp.UnixMicro()))...))); err != nil {
+		t.Fatal(err)
+	}
+	if pt, ok := got[2].PrecisionTime(); !ok || !pt.Equal(stamp) {
+		t.Fatalf("PrecisionTime() = %v, %v; want %v, true", pt, ok, stamp)
+	}
+	if err := p.ProcessChunk(pkt(true, item(2, 1, 2, 3))); err != nil {
+		t.Fatal(err)
+	}
+	if pt, ok := got[2].PrecisionTime(); ok {
+		t.Errorf("PrecisionTime() of a truncated time stamp = %v, want none", pt)
+	}
+}
+
+func TestLenientTimestamps(t *testing.T) {
+	packet := pkt(true, item(2, u32(1700000000)...))
+	for _, lenient := range []bool{false, true} {
+		var errs []error
+		var tags map[int]*KLVTag
+		p := NewKLVParser(func(m map[int]*KLVTag) { tags = m })
+		p.OnError = func(tag int, err error) { errs = append(errs, err) }
+		p.SetLenientTimestamps(lenient)
+		if err := p.ProcessChunk(packet); err != nil {
+			t.Fatal(err)
+		}
+		stamp, ok := tags[2].PrecisionTime()
+		if lenient {
+			if want := time.Unix(1700000000, 0).UTC(); !ok || !stamp.Equal(want) || len(errs) != 0 {
+				t.Errorf("lenient: time %v, %v, errors %v; want %v", stamp, ok, errs, want)
+			}
+			continue
+		}
+		if ok || tags[2].Status != StatusTruncated || len(errs) != 1 || !errors.Is(errs[0], ErrInvalidLength) {
+			t.Errorf("strict: time %v, %v, status %v, errors %v; want ErrInvalidLength", stamp, ok, tags[2].Status, errs)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package klvparser
+
+// Pressure tags (Static Pressure, Differential Pressure and Airfield Barometric
+// Pressure) are decoded in hectopascals, which equal millibars.
+const (
+	hPaPerKPa  = 10
+	hPaPerInHg = 33.8638866667
+)
+
+// PressureHPa returns the value of a pressure tag in hectopascals. ok is false
+// for tags that do not hold a pressure and for pressures that were not decoded.
+func (t *KLVTag) PressureHPa() (float64, bool) {
+	if t == nil || t.Unit != "hPa" {
+		return 0, false
+	}
+	value, ok := t.Value.(float64)
+	if !ok || value != value {
+		return 0, false
+	}
+	return value, true
+}
+
+// PressureKPa returns the value of a pressure tag in kilopascals.
+func (t *KLVTag) PressureKPa() (float64, bool) {
+	hPa, ok := t.PressureHPa()
+	return hPa / hPaPerKPa, ok
+}
+
+// PressureInHg returns the value of a pressure tag in inches of mercury.
+func (t *KLVTag) PressureInHg() (float64, bool) {
+	hPa, ok := t.PressureHPa()
+	return hPa / hPaPerInHg, ok
+}
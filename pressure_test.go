@@ -0,0 +1,26 @@
+package klvparser
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPressure(t *testing.T) {
+	tags := decodeOne(t, pkt(true, item(49, 0xFF, 0xFF), item(5, 1, 1)))
+	if hPa, ok := tags[49].PressureHPa(); !ok || hPa != 5000 {
+		t.Errorf("PressureHPa = %v, %v, want 5000", hPa, ok)
+	}
+	if kPa, ok := tags[49].PressureKPa(); !ok || kPa != 500 {
+		t.Errorf("PressureKPa = %v, %v, want 500", kPa, ok)
+	}
+	if inHg, ok := tags[49].PressureInHg(); !ok || math.Abs(inHg-147.65) > 0.01 {
+		t.Errorf("PressureInHg = %v, %v, want 147.65", inHg, ok)
+	}
+	if _, ok := tags[5].PressureHPa(); ok {
+		t.Error("PressureHPa of a heading is ok")
+	}
+	var missing *KLVTag
+	if _, ok := missing.PressureKPa(); ok {
+		t.Error("PressureKPa of a missing tag is ok")
+	}
+}
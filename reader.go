@@ -0,0 +1,108 @@
+package klvparser
+
+import (
+	"context"
+	"errors"
+	"io"
+	"iter"
+)
+
+// defaultChunkSize is the number of bytes ParseStream reads at a time unless
+// configured otherwise with SetChunkSize.
+const defaultChunkSize = 4096
+
+// SetChunkSize sets the number of bytes ParseStream reads at a time.
+// A size of 0 or less restores the default.
+func (p *KLVParser) SetChunkSize(size int) {
+	p.chunkSize = size
+}
+
+// ParseStream reads r until it is exhausted, feeding the data through ProcessChunk.
+// It returns nil once r reports io.EOF, and any other read or processing error
+// as soon as it occurs.
+func (p *KLVParser) ParseStream(r io.Reader) error {
+	return p.ParseStreamContext(context.Background(), r)
+}
+
+// ParseStreamContext is like ParseStream but stops between packets once ctx is
+// done, returning ctx.Err(). A Read that blocks is not interrupted; close the
+// underlying source on cancellation to unblock it.
+func (p *KLVParser) ParseStreamContext(ctx context.Context, r io.Reader) error {
+	size := p.chunkSize
+	if size <= 0 {
+		size = defaultChunkSize
+	}
+	chunk := make([]byte, size)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n, err := r.Read(chunk)
+		if n > 0 {
+			if perr := p.processChunk(ctx, chunk[:n]); perr != nil {
+				return perr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Packets returns an iterator over the packets decoded from r, for use with range:
+//
+//	for tags, err := range parser.Packets(r) {
+//		if err != nil {
+//			// handle the read error
+//			break
+//		}
+//		// use tags
+//	}
+//
+// It reads r in chunks like ParseStream and yields the tags of each packet in
+// turn. A read or processing error is yielded with nil tags and ends the
+// iteration, as does io.EOF, which is not yielded. Decoding anomalies still go
+// to OnError. While iterating, the packets are delivered to the loop rather
+// than the tag callback. When the loop breaks, the packets read but not yet
+// decoded stay buffered for the next call.
+func (p *KLVParser) Packets(r io.Reader) iter.Seq2[map[int]*KLVTag, error] {
+	return func(yield func(map[int]*KLVTag, error) bool) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		callback := p.callback
+		defer func() { p.callback = callback }()
+		p.callback = func(tags map[int]*KLVTag) {
+			if !yield(tags, nil) {
+				// Stop before the next packet, leaving it buffered.
+				cancel()
+			}
+		}
+
+		size := p.chunkSize
+		if size <= 0 {
+			size = defaultChunkSize
+		}
+		chunk := make([]byte, size)
+		for ctx.Err() == nil {
+			n, err := r.Read(chunk)
+			if n > 0 {
+				if perr := p.processChunk(ctx, chunk[:n]); perr != nil {
+					if ctx.Err() == nil {
+						yield(nil, perr)
+					}
+					return
+				}
+			}
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
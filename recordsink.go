@@ -0,0 +1,61 @@
+package klvparser
+
+import (
+	"encoding/binary"
+	"io"
+	"sync"
+)
+
+// RecordSink writes decoded frames to an io.Writer as key/value records that a
+// message producer, such as a Kafka client, can relay unchanged. Each record is
+//
+//	key length (4 bytes, big-endian)
+//	key: the Precision Time Stamp (tag 2) as 8 big-endian bytes of microseconds,
+//	or empty when the frame has no time stamp
+//	value length (4 bytes, big-endian)
+//	value: the frame as a JSON object keyed by tag ID
+type RecordSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewRecordSink returns a sink writing records to w.
+func NewRecordSink(w io.Writer) *RecordSink {
+	return &RecordSink{w: w}
+}
+
+// WriteFrame writes a decoded frame as a single record.
+func (s *RecordSink) WriteFrame(tags map[int]*KLVTag) error {
+	value, err := MarshalTags(tags)
+	if err != nil {
+		return err
+	}
+	key := RecordKey(tags)
+
+	record := make([]byte, 8+len(key)+len(value))
+	binary.BigEndian.PutUint32(record, uint32(len(key)))
+	copy(record[4:], key)
+	binary.BigEndian.PutUint32(record[4+len(key):], uint32(len(value)))
+	copy(record[8+len(key):], value)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(record)
+	return err
+}
+
+// RecordKey returns the record key RecordSink uses for a frame: its Precision
+// Time Stamp (tag 2) as 8 big-endian bytes, or nil when the frame has none.
+func RecordKey(tags map[int]*KLVTag) []byte {
+	var micros uint64
+	if ts, ok := tags[2].PrecisionTime(); ok {
+		micros = uint64(ts.UnixMicro())
+	} else if timestamp, ok := tagFloat(tags, 2); ok && timestamp >= 0 {
+		micros = uint64(timestamp)
+	} else {
+		return nil
+	}
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, micros)
+	return key
+}
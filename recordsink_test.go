@@ -0,0 +1,46 @@
+package klvparser
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+)
+
+func TestRecordSink(t *testing.T) {
+	var out bytes.Buffer
+	sink := NewRecordSink(&out)
+	p := NewKLVParser(func(tags map[int]*KLVTag) {
+		if err := sink.WriteFrame(tags); err != nil {
+			t.Fatal(err)
+		}
+	})
+	stamps := []uint64{1700000000000000, 1700000000040000}
+	chunk := append(pkt(true, item(2, u64(stamps[0])...), item(5, u16(1)...)),
+		pkt(true, item(2, u64(stamps[1])...), item(5, u16(2)...))...)
+	if err := p.ProcessChunk(chunk); err != nil {
+		t.Fatal(err)
+	}
+
+	records := out.Bytes()
+	for i, stamp := range stamps {
+		if len(records) < 4 {
+			t.Fatalf("record %d missing", i)
+		}
+		keyLength := binary.BigEndian.Uint32(records)
+		key := records[4 : 4+keyLength]
+		if keyLength != 8 || binary.BigEndian.Uint64(key) != stamp {
+			t.Errorf("record %d key = % x, want %d", i, key, stamp)
+		}
+		records = records[4+keyLength:]
+		valueLength := binary.BigEndian.Uint32(records)
+		value := records[4 : 4+valueLength]
+		if !json.Valid(value) {
+			t.Errorf("record %d value is not JSON: %s", i, value)
+		}
+		records = records[4+valueLength:]
+	}
+	if len(records) != 0 {
+		t.Errorf("%d bytes left after two records", len(records))
+	}
+}
@@ -0,0 +1,106 @@
+package klvparser
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// SDCCMatrix is a decoded SDCC-FLP (tag 102) standard deviation and
+// cross-correlation matrix.
+type SDCCMatrix struct {
+	Tags   []int       // The tags described by each row and column, in matrix order
+	Matrix [][]float64 // Standard deviations on the diagonal, correlation coefficients elsewhere
+}
+
+// StdDev returns the standard deviation the matrix gives for a tag.
+func (m SDCCMatrix) StdDev(tag int) (float64, bool) {
+	for i, t := range m.Tags {
+		if t == tag {
+			return m.Matrix[i][i], true
+		}
+	}
+	return 0, false
+}
+
+// SetAttachUncertainty makes the parser attach the standard deviations of the
+// packet's SDCC-FLP matrix (tag 102) to the tags it describes, such as the
+// sensor latitude and longitude, as their Uncertainty.
+func (p *KLVParser) SetAttachUncertainty(attach bool) {
+	p.attachUncertainty = attach
+}
+
+// attachSDCCUncertainty sets the Uncertainty of the tags covered by the packet's
+// SDCC-FLP matrix when enabled.
+func (p *KLVParser) attachSDCCUncertainty(parsedTags map[int]*KLVTag) {
+	if !p.attachUncertainty || parsedTags[102] == nil {
+		return
+	}
+	matrix, ok := parsedTags[102].Value.(SDCCMatrix)
+	if !ok {
+		return
+	}
+	for i, tag := range matrix.Tags {
+		if data := parsedTags[tag]; data != nil {
+			stdDev := fromBaseUnit(data.Unit, matrix.Matrix[i][i])
+			data.Uncertainty = &stdDev
+		}
+	}
+}
+
+// extractSDCC decodes an SDCC-FLP pack laid out as:
+//
+//	matrix size n (1 byte)
+//	the n tags described by the matrix (1 byte each)
+//	parse control (1 byte): bits 7-4 hold the length of each standard deviation,
+//	bits 3-0 the length of each correlation coefficient (0 when absent)
+//	n standard deviations as IEEE 754 floats of 4 or 8 bytes
+//	n(n-1)/2 correlation coefficients of the upper triangle, row by row, as IMAPB(-1, 1)
+//
+// It returns nil when the value does not match this layout.
+func extractSDCC(value []byte) *SDCCMatrix {
+	if len(value) < 2 {
+		return nil
+	}
+	n := int(value[0])
+	if n == 0 || len(value) < n+2 {
+		return nil
+	}
+	tags := make([]int, n)
+	for i := range tags {
+		tags[i] = int(value[1+i])
+	}
+	control := value[n+1]
+	sdLength := int(control >> 4)
+	ccLength := int(control & 0x0F)
+	if sdLength != 4 && sdLength != 8 {
+		return nil
+	}
+	data := value[n+2:]
+	correlations := n * (n - 1) / 2
+	if len(data) != n*sdLength+correlations*ccLength {
+		return nil
+	}
+
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, n)
+		element := data[i*sdLength : (i+1)*sdLength]
+		if sdLength == 4 {
+			matrix[i][i] = float64(math.Float32frombits(binary.BigEndian.Uint32(element)))
+		} else {
+			matrix[i][i] = math.Float64frombits(binary.BigEndian.Uint64(element))
+		}
+	}
+	if ccLength > 0 {
+		offset := n * sdLength
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				coefficient := imapbFraction(data[offset:offset+ccLength])*2 - 1
+				matrix[i][j] = coefficient
+				matrix[j][i] = coefficient
+				offset += ccLength
+			}
+		}
+	}
+	return &SDCCMatrix{Tags: tags, Matrix: matrix}
+}
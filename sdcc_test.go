@@ -0,0 +1,60 @@
+package klvparser
+
+import (
+	"math"
+	"testing"
+)
+
+// sdccPack encodes an SDCC-FLP pack of the standard deviations of tags 13 and 14
+// as float32 values, with every correlation at 1.
+func sdccPack(latitude, longitude float32) []byte {
+	pack := []byte{2, 13, 14, 0x42}
+	pack = append(pack, u32(math.Float32bits(latitude))...)
+	pack = append(pack, u32(math.Float32bits(longitude))...)
+	return append(pack, 0xFF, 0xFF)
+}
+
+func TestSDCCMatrix(t *testing.T) {
+	matrix := decodeOne(t, pkt(true, item(102, sdccPack(1.5, 2.5)...)))[102].Value.(SDCCMatrix)
+	if len(matrix.Matrix) != 2 || matrix.Matrix[0][0] != 1.5 || matrix.Matrix[1][1] != 2.5 || matrix.Matrix[0][1] != 1 {
+		t.Fatalf("matrix = %+v", matrix)
+	}
+	if sd, ok := matrix.StdDev(14); !ok || sd != 2.5 {
+		t.Errorf("StdDev(14) = %v, %v; want 2.5, true", sd, ok)
+	}
+}
+
+func TestSDCCUncertainty(t *testing.T) {
+	var got map[int]*KLVTag
+	p := NewKLVParser(func(tags map[int]*KLVTag) { got = tags })
+	packet := pkt(true, item(13, u32(0x10000000)...), item(102, sdccPack(1.5, 2.5)...))
+	if err := p.ProcessChunk(packet); err != nil {
+		t.Fatal(err)
+	}
+	if got[13].Uncertainty != nil {
+		t.Fatal("uncertainty attached without SetAttachUncertainty")
+	}
+
+	p.SetAttachUncertainty(true)
+	if err := p.ProcessChunk(packet); err != nil {
+		t.Fatal(err)
+	}
+	if u := got[13].Uncertainty; u == nil || *u != 1.5 {
+		t.Fatalf("latitude uncertainty = %v, want 1.5", u)
+	}
+
+	p.Units.Angle = AngleRadians
+	if err := p.ProcessChunk(packet); err != nil {
+		t.Fatal(err)
+	}
+	if u := got[13].Uncertainty; u == nil || math.Abs(*u-1.5*math.Pi/180) > 1e-12 {
+		t.Fatalf("latitude uncertainty in radians = %v, want %v", u, 1.5*math.Pi/180)
+	}
+
+	if err := p.ProcessChunk(pkt(true, item(13, u32(0x10000000)...))); err != nil {
+		t.Fatal(err)
+	}
+	if got[13].Uncertainty != nil {
+		t.Error("uncertainty kept from a previous packet")
+	}
+}
@@ -0,0 +1,117 @@
+package klvparser
+
+import "fmt"
+
+// securityClassifications names the values of Security Classification (tag 1 of
+// the Security Metadata local set).
+var securityClassifications = map[int]string{
+	1: "UNCLASSIFIED",
+	2: "RESTRICTED",
+	3: "CONFIDENTIAL",
+	4: "SECRET",
+	5: "TOP SECRET",
+}
+
+// countryCodingMethods names the values of Country Coding Method (tag 2 of the
+// Security Metadata local set) and Object Country Coding Method (tag 12).
+var countryCodingMethods = map[int]string{
+	1:  "ISO-3166 Two Letter",
+	2:  "ISO-3166 Three Letter",
+	3:  "FIPS 10-4 Two Letter",
+	4:  "FIPS 10-4 Four Letter",
+	5:  "ISO-3166 Numeric",
+	6:  "1059 Two Letter",
+	7:  "1059 Three Letter",
+	10: "FIPS 10-4 Mixed",
+	11: "ISO 3166 Mixed",
+	12: "STANAG 1059 Mixed",
+	13: "GENC Two Letter",
+	14: "GENC Three Letter",
+	15: "GENC Numeric",
+	16: "GENC Mixed",
+	64: "GENC AdminSub",
+}
+
+// SecurityMarking is the value of a decoded Security Local Metadata Set (tag 48):
+// its classification and how its countries are coded, by name and by code. A code
+// the standard does not define is named "unknown code n". The items themselves
+// are the tag's children.
+type SecurityMarking struct {
+	Classification          string // Such as "SECRET"
+	ClassificationCode      int    // 0 when absent
+	CountryCodingMethod     string // Such as "ISO-3166 Two Letter"
+	CountryCodingMethodCode int    // 0 when absent
+	ClassifyingCountry      string // Coded as set by the Country Coding Method
+}
+
+// processSecuritySet decodes the Security Local Metadata Set (tag 48) and stores a
+// SecurityMarking as the tag value, or the hex dump when none of its items decoded.
+func (p *KLVParser) processSecuritySet(tag int, value []byte) {
+	p.processNestedSet(tag, value)
+	meta := p.tagMeta[tag]
+	if meta == nil || len(meta.Children) == 0 {
+		return
+	}
+	var marking SecurityMarking
+	if child := meta.Children[1]; child != nil {
+		if code, ok := child.Value.(float64); ok {
+			marking.ClassificationCode = int(code)
+			marking.Classification = codeName(securityClassifications, int(code))
+		}
+	}
+	if child := meta.Children[2]; child != nil {
+		if code, ok := child.Value.(float64); ok {
+			marking.CountryCodingMethodCode = int(code)
+			marking.CountryCodingMethod = codeName(countryCodingMethods, int(code))
+		}
+	}
+	if child := meta.Children[3]; child != nil {
+		marking.ClassifyingCountry, _ = child.Value.(string)
+	}
+	meta.Value = marking
+}
+
+// codeName returns the name of an enumerated code, or a note with the code when
+// the enumeration does not define it.
+func codeName(names map[int]string, code int) string {
+	if name, ok := names[code]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown code %d", code)
+}
+
+// Classification returns the security classification carried in the Security
+// Local Metadata Set (tag 48), such as "SECRET".
+func Classification(tags map[int]*KLVTag) (string, bool) {
+	child, ok := securityItem(tags, 1)
+	if !ok {
+		return "", false
+	}
+	value, ok := child.Value.(float64)
+	if !ok {
+		return "", false
+	}
+	name, ok := securityClassifications[int(value)]
+	return name, ok
+}
+
+// ClassifyingCountry returns the classifying country carried in the Security
+// Local Metadata Set (tag 48), coded as set by its Country Coding Method.
+func ClassifyingCountry(tags map[int]*KLVTag) (string, bool) {
+	child, ok := securityItem(tags, 3)
+	if !ok {
+		return "", false
+	}
+	country, ok := child.Value.(string)
+	return country, ok
+}
+
+// securityItem returns an item of the Security Local Metadata Set.
+func securityItem(tags map[int]*KLVTag, item int) (*KLVTag, bool) {
+	set, ok := tags[48]
+	if !ok || set == nil {
+		return nil, false
+	}
+	child, ok := set.Children[item]
+	return child, ok && child != nil
+}
@@ -0,0 +1,125 @@
+package klvparser
+
+import "time"
+
+// Segment is a decoded Segment Local Set (tag 100). A packet may carry several,
+// each grouping MISB ST 0601 tags that belong together, such as the values for
+// one part of the image.
+type Segment struct {
+	Index int             // Position of the segment in its packet, or in its frame once assembled, from 0
+	Count int             // Number of segments in the packet, or in the frame once assembled
+	Tags  map[int]*KLVTag // The segment's tags, decoded with the regular tag decoders
+}
+
+// processSegmentSet decodes a Segment Local Set and adds it to the segments of the
+// current packet, which make up the tag value.
+func (p *KLVParser) processSegmentSet(tag int, value []byte) {
+	meta := p.tagMeta[tag]
+	if meta == nil {
+		return
+	}
+	p.segments = append(p.segments, Segment{Index: len(p.segments), Tags: p.decodeEmbeddedSet(tag, value)})
+	meta.Value = numberSegments(p.segments)
+}
+
+// numberSegments returns a copy of segments with each Index set to its position
+// and each Count to the number of segments.
+func numberSegments(segments []Segment) []Segment {
+	numbered := make([]Segment, len(segments))
+	for i, segment := range segments {
+		segment.Index, segment.Count = i, len(segments)
+		numbered[i] = segment
+	}
+	return numbered
+}
+
+// MergeSegments reassembles a packet carrying Segment Local Sets (tag 100) into a
+// single tag map: the packet's other tags, overlaid with the tags of every segment
+// in order, so a tag repeated by a later segment replaces the earlier value. It
+// returns false when the packet carries no segments.
+func MergeSegments(tags map[int]*KLVTag) (map[int]*KLVTag, bool) {
+	data, ok := tags[100]
+	if !ok || data == nil {
+		return nil, false
+	}
+	if segments, ok := data.Value.([]Segment); !ok || len(segments) == 0 {
+		return nil, false
+	}
+	return mergeFrame([]map[int]*KLVTag{tags}), true
+}
+
+// SegmentAssembler reassembles the segments of a frame sent across several
+// packets. Packets carrying the same Precision Time Stamp (tag 2) belong to the
+// same frame; a packet without one is a frame of its own. The zero value is ready
+// to use. The tag maps passed to Add are kept until their frame completes, so
+// they must not be reused through SetReuseMap.
+type SegmentAssembler struct {
+	packets []map[int]*KLVTag
+	time    time.Time // Precision Time Stamp of the frame being assembled
+	timed   bool      // Whether the frame being assembled has a Precision Time Stamp
+}
+
+// Add adds the tags of a decoded packet to the frame being assembled. A packet of
+// a new frame completes the previous one, which is returned merged as by
+// MergeSegments; its tag 100 holds every segment of the frame, numbered across
+// its packets. ok is false while no frame is complete.
+func (a *SegmentAssembler) Add(tags map[int]*KLVTag) (frame map[int]*KLVTag, ok bool) {
+	stamp, timed := tags[2].PrecisionTime()
+	if len(a.packets) > 0 && (!timed || !a.timed || !stamp.Equal(a.time)) {
+		frame, ok = a.Flush()
+	}
+	a.packets = append(a.packets, tags)
+	a.time, a.timed = stamp, timed
+	return frame, ok
+}
+
+// Flush returns the frame being assembled, merged as by Add, and starts a new one.
+// Call it at the end of the stream for the last frame. ok is false when no packet
+// was added since the last frame.
+func (a *SegmentAssembler) Flush() (map[int]*KLVTag, bool) {
+	if len(a.packets) == 0 {
+		return nil, false
+	}
+	frame := mergeFrame(a.packets)
+	var segments []Segment
+	for _, tags := range a.packets {
+		if data := tags[100]; data != nil {
+			packetSegments, _ := data.Value.([]Segment)
+			segments = append(segments, packetSegments...)
+		}
+	}
+	if len(segments) > 0 {
+		segmentTag := tagDefinitions[100].newTag()
+		segmentTag.Value = numberSegments(segments)
+		frame[100] = segmentTag
+	}
+	a.packets = nil
+	return frame, true
+}
+
+// mergeFrame merges the tags of the packets of a frame: the tags outside Segment
+// Local Sets in packet order, then overlaid with the tags of every segment in
+// order, so a later value of a tag replaces an earlier one.
+func mergeFrame(packets []map[int]*KLVTag) map[int]*KLVTag {
+	merged := make(map[int]*KLVTag, len(packets[0]))
+	for _, tags := range packets {
+		for tag, value := range tags {
+			if tag != 100 {
+				merged[tag] = value
+			}
+		}
+	}
+	for _, tags := range packets {
+		data := tags[100]
+		if data == nil {
+			continue
+		}
+		segments, _ := data.Value.([]Segment)
+		for _, segment := range segments {
+			for tag, value := range segment.Tags {
+				merged[tag] = value
+			}
+		}
+	}
+	return merged
+}
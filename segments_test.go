@@ -0,0 +1,72 @@
+package klvparser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMergeSegments(t *testing.T) {
+	first := append(item(13, u32(1)...), item(5, u16(1)...)...)
+	second := append(item(13, u32(2)...), item(14, u32(3)...)...)
+	tags := decodeOne(t, pkt(true, item(2, u64(5)...), item(100, first...), item(100, second...)))
+	segments := tags[100].Value.([]Segment)
+	if len(segments) != 2 || segments[0].Index != 0 || segments[1].Index != 1 || segments[1].Count != 2 {
+		t.Fatalf("segments = %+v", segments)
+	}
+	merged, ok := MergeSegments(tags)
+	if !ok {
+		t.Fatal("MergeSegments failed")
+	}
+	if merged[2] == nil || merged[5] == nil || merged[14] == nil || merged[100] != nil {
+		t.Errorf("merged tags = %v", merged)
+	}
+	if merged[13].Value != segments[1].Tags[13].Value {
+		t.Errorf("latitude = %v, want the second segment's %v", merged[13].Value, segments[1].Tags[13].Value)
+	}
+}
+
+func TestSegmentAssembler(t *testing.T) {
+	var assembler SegmentAssembler
+	var frames []map[int]*KLVTag
+	p := NewKLVParser(func(tags map[int]*KLVTag) {
+		if frame, ok := assembler.Add(tags); ok {
+			frames = append(frames, frame)
+		}
+	})
+	stamp := func(seconds int64) []byte {
+		return item(2, u64(uint64(time.Unix(seconds, 0).UnixMicro()))...)
+	}
+	// The first frame is split over two packets with a segment each.
+	chunk := pkt(true, stamp(10), item(65, 19), item(100, item(13, u32(1)...)...))
+	chunk = append(chunk, pkt(true, stamp(10), item(100, item(14, u32(2)...)...))...)
+	chunk = append(chunk, pkt(true, stamp(11), item(100, item(13, u32(3)...)...))...)
+	if err := p.ProcessChunk(chunk); err != nil {
+		t.Fatal(err)
+	}
+	if len(frames) != 1 {
+		t.Fatalf("completed %d frames, want 1", len(frames))
+	}
+	if last, ok := assembler.Flush(); ok {
+		frames = append(frames, last)
+	}
+	if len(frames) != 2 {
+		t.Fatalf("flushed %d frames, want 2", len(frames))
+	}
+
+	first := frames[0]
+	for _, tag := range []int{2, 13, 14, 65} {
+		if first[tag] == nil {
+			t.Errorf("first frame lacks tag %d", tag)
+		}
+	}
+	segments := first[100].Value.([]Segment)
+	if len(segments) != 2 || segments[1].Index != 1 || segments[0].Count != 2 {
+		t.Errorf("first frame segments = %+v", segments)
+	}
+	if at, _ := first[2].PrecisionTime(); !at.Equal(time.Unix(10, 0)) {
+		t.Errorf("first frame time = %v", at)
+	}
+	if second := frames[1]; second[14] != nil || len(second[100].Value.([]Segment)) != 1 {
+		t.Errorf("second frame = %v", second)
+	}
+}
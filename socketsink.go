@@ -0,0 +1,113 @@
+package klvparser
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// socketWriteTimeout bounds how long a slow client may stall a frame write.
+const socketWriteTimeout = time.Second
+
+// SocketSink writes decoded frames as newline-delimited JSON to connected clients,
+// typically over a Unix domain socket. Clients that disconnect or fail a write are
+// dropped without affecting the others.
+type SocketSink struct {
+	mu       sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	closed   bool
+}
+
+// NewSocketSink returns a sink without connections; use AddConn to attach them.
+func NewSocketSink() *SocketSink {
+	return &SocketSink{conns: make(map[net.Conn]struct{})}
+}
+
+// ListenUnixSink listens on a Unix domain socket and sends frames to every client that connects.
+func ListenUnixSink(path string) (*SocketSink, error) {
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	s := NewSocketSink()
+	s.listener = listener
+	go s.accept()
+	return s, nil
+}
+
+// DialUnixSink connects to a Unix domain socket and sends frames to it.
+func DialUnixSink(path string) (*SocketSink, error) {
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	s := NewSocketSink()
+	s.AddConn(conn)
+	return s, nil
+}
+
+// accept adds incoming clients until the listener is closed.
+func (s *SocketSink) accept() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		s.AddConn(conn)
+	}
+}
+
+// AddConn attaches a connection that receives subsequent frames.
+func (s *SocketSink) AddConn(conn net.Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		conn.Close()
+		return
+	}
+	s.conns[conn] = struct{}{}
+}
+
+// WriteFrame sends a frame as a single JSON line to every connected client.
+func (s *SocketSink) WriteFrame(tags map[int]*KLVTag) error {
+	line, err := MarshalTags(tags)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errors.New("socket sink is closed")
+	}
+	for conn := range s.conns {
+		conn.SetWriteDeadline(time.Now().Add(socketWriteTimeout))
+		if _, err := conn.Write(line); err != nil {
+			conn.Close()
+			delete(s.conns, conn)
+		}
+	}
+	return nil
+}
+
+// Close stops accepting clients and closes all connections.
+func (s *SocketSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	var err error
+	if s.listener != nil {
+		err = s.listener.Close()
+	}
+	for conn := range s.conns {
+		conn.Close()
+		delete(s.conns, conn)
+	}
+	return err
+}
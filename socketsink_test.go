@@ -0,0 +1,62 @@
+package klvparser
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+)
+
+func TestSocketSinkUnix(t *testing.T) {
+	listener, err := net.Listen("unix", filepath.Join(t.TempDir(), "frames.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			close(accepted)
+			return
+		}
+		accepted <- conn
+	}()
+
+	sink, err := DialUnixSink(listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, ok := <-accepted
+	if !ok {
+		t.Fatal("no connection accepted")
+	}
+	defer conn.Close()
+
+	tags := decodeOne(t, pkt(true, item(5, u16(0x8000)...)))
+	if err := sink.WriteFrame(tags); err != nil {
+		t.Fatal(err)
+	}
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	var frame map[string]struct {
+		Name  string  `json:"name"`
+		Value float64 `json:"value"`
+	}
+	if err := json.Unmarshal(line, &frame); err != nil {
+		t.Fatalf("line %q: %v", line, err)
+	}
+	if heading := frame["5"]; heading.Name != "Platform Heading Angle" || heading.Value != tags[5].Value {
+		t.Errorf("heading = %+v, want %v", heading, tags[5].Value)
+	}
+
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.WriteFrame(tags); err == nil {
+		t.Error("WriteFrame after Close succeeded")
+	}
+}
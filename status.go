@@ -0,0 +1,35 @@
+package klvparser
+
+import "strconv"
+
+// TagStatus tells how a tag present in a packet was decoded.
+type TagStatus int
+
+const (
+	// StatusOK means the value was decoded.
+	StatusOK TagStatus = iota
+	// StatusTruncated means the value was too short, or otherwise the wrong length,
+	// for its encoding. Value is nil.
+	StatusTruncated
+	// StatusOutOfBounds means the value fell outside the tag's range, or carried the
+	// reserved "out of range" indicator or an IMAPB infinity. Value is nil.
+	StatusOutOfBounds
+	// StatusNull means the value carried the encoding's null indicator, such as the
+	// IMAPB NaN code. Value is nil.
+	StatusNull
+)
+
+// String returns the name of the status.
+func (s TagStatus) String() string {
+	switch s {
+	case StatusOK:
+		return "OK"
+	case StatusTruncated:
+		return "Truncated"
+	case StatusOutOfBounds:
+		return "OutOfBounds"
+	case StatusNull:
+		return "Null"
+	}
+	return "TagStatus(" + strconv.Itoa(int(s)) + ")"
+}
@@ -0,0 +1,13 @@
+package klvparser
+
+// StorageStatus combines the On-board MI Storage Capacity (tag 133) and Percent
+// Full (tag 120) into the capacity, the used space and the free space, all in GB.
+func StorageStatus(tags map[int]*KLVTag) (capacityGB, usedGB, freeGB float64, ok bool) {
+	values, ok := tagFloats(tags, 133, 120)
+	if !ok {
+		return 0, 0, 0, false
+	}
+	capacityGB = values[0]
+	usedGB = capacityGB * values[1] / 100
+	return capacityGB, usedGB, capacityGB - usedGB, true
+}
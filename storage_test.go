@@ -0,0 +1,20 @@
+package klvparser
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStorageStatus(t *testing.T) {
+	// 1000 GB at 40% full, with 0x6666 being 40% of 0xFFFF.
+	tags := decodeOne(t, pkt(true, item(120, 2, 0x66, 0x66), item(133, u32(1000)...)))
+	capacity, used, free, ok := StorageStatus(tags)
+	if !ok || capacity != 1000 || math.Abs(used-400) > 1e-9 || math.Abs(free-600) > 1e-9 {
+		t.Errorf("StorageStatus = %v, %v, %v, %v, want 1000, 400, 600", capacity, used, free, ok)
+	}
+
+	delete(tags, 120)
+	if _, _, _, ok := StorageStatus(tags); ok {
+		t.Error("StorageStatus without Percent Full is ok")
+	}
+}
@@ -0,0 +1,51 @@
+package klvparser
+
+import (
+	"fmt"
+	"math"
+)
+
+// SetCheckTimestamps enables a check that the Precision Time Stamp (tag 2) never
+// goes backwards between packets. Violations are reported through OnError with
+// ErrNonMonotonicTimestamp.
+func (p *KLVParser) SetCheckTimestamps(check bool) {
+	p.checkTimes = check
+	p.lastTimestamp = 0
+}
+
+// checkTimestamp compares a packet's Precision Time Stamp against the previous one.
+func (p *KLVParser) checkTimestamp(parsedTags map[int]*KLVTag) {
+	if !p.checkTimes {
+		return
+	}
+	timestamp, ok := tagFloat(parsedTags, 2)
+	if !ok {
+		return
+	}
+	if timestamp < p.lastTimestamp {
+		p.reportError(2, fmt.Errorf("%w: %.0f follows %.0f", ErrNonMonotonicTimestamp, timestamp, p.lastTimestamp))
+	}
+	p.lastTimestamp = timestamp
+}
+
+// DroppedFrames estimates how many frames are missing from a window of consecutive
+// Precision Time Stamps (microseconds since the epoch) given the expected frame rate.
+// Gaps are rounded to the nearest whole number of frame intervals, so small jitter
+// is not counted as a drop; timestamps that go backwards are ignored.
+func DroppedFrames(timestamps []uint64, frameRate float64) int {
+	if frameRate <= 0 {
+		return 0
+	}
+	interval := 1e6 / frameRate
+	dropped := 0
+	for i := 1; i < len(timestamps); i++ {
+		if timestamps[i] <= timestamps[i-1] {
+			continue
+		}
+		frames := int(math.Round(float64(timestamps[i]-timestamps[i-1]) / interval))
+		if frames > 1 {
+			dropped += frames - 1
+		}
+	}
+	return dropped
+}
@@ -0,0 +1,59 @@
+package klvparser
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckTimestamps(t *testing.T) {
+	tests := []struct {
+		name       string
+		timestamps []uint64
+		errors     int
+	}{
+		{"ascending", []uint64{100, 200, 300}, 0},
+		{"equal", []uint64{100, 200, 200}, 0},
+		{"backwards", []uint64{100, 300, 200}, 1},
+	}
+	for _, tt := range tests {
+		var errs []error
+		p := NewKLVParser(func(map[int]*KLVTag) {})
+		p.OnError = func(tag int, err error) { errs = append(errs, err) }
+		p.SetCheckTimestamps(true)
+		for _, timestamp := range tt.timestamps {
+			if err := p.ProcessChunk(pkt(true, item(2, u64(timestamp)...))); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if len(errs) != tt.errors {
+			t.Errorf("%s: errors %v, want %d", tt.name, errs, tt.errors)
+		}
+		for _, err := range errs {
+			if !errors.Is(err, ErrNonMonotonicTimestamp) || !strings.Contains(err.Error(), "200 follows 300") {
+				t.Errorf("%s: error %v, want ErrNonMonotonicTimestamp with both time stamps", tt.name, err)
+			}
+		}
+	}
+}
+
+func TestDroppedFrames(t *testing.T) {
+	// 25 frames per second: 40000 µs apart, with slight jitter.
+	tests := []struct {
+		name       string
+		timestamps []uint64
+		want       int
+	}{
+		{"no gap", []uint64{0, 40000, 80100, 119900}, 0},
+		{"two frames missing", []uint64{0, 40000, 160000, 200000}, 2},
+		{"backwards", []uint64{0, 40000, 0, 40000}, 0},
+	}
+	for _, tt := range tests {
+		if got := DroppedFrames(tt.timestamps, 25); got != tt.want {
+			t.Errorf("%s: DroppedFrames = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+	if got := DroppedFrames([]uint64{0, 1000000}, 0); got != 0 {
+		t.Errorf("DroppedFrames without a frame rate = %d, want 0", got)
+	}
+}
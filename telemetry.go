@@ -0,0 +1,122 @@
+package klvparser
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"time"
+)
+
+// Telemetry holds the commonly used platform and sensor fields of a MISB ST 0601 packet.
+// Fields whose tag is absent from the packet are NaN, except Timestamp which is 0.
+type Telemetry struct {
+	Timestamp            uint64  // Precision Time Stamp in microseconds since the Unix epoch (tag 2)
+	PlatformHeading      float64 // Degrees (tag 5)
+	PlatformPitch        float64 // Degrees (tag 6)
+	PlatformRoll         float64 // Degrees (tag 7)
+	SensorLatitude       float64 // Degrees (tag 13)
+	SensorLongitude      float64 // Degrees (tag 14)
+	SensorAltitude       float64 // Meters (tag 15)
+	FrameCenterLatitude  float64 // Degrees (tag 23)
+	FrameCenterLongitude float64 // Degrees (tag 24)
+	FrameCenterElevation float64 // Meters (tag 25)
+}
+
+// telemetryTags lists the tags backing the float fields of Telemetry, in record order.
+var telemetryTags = []int{5, 6, 7, 13, 14, 15, 23, 24, 25}
+
+// telemetryRecordSize is the size of a binary Telemetry record: a byte-order marker,
+// the timestamp, and one float64 per entry of telemetryTags.
+const telemetryRecordSize = 1 + 8 + 8*9
+
+// Byte-order markers leading a binary Telemetry record.
+const (
+	bigEndianMarker    = 'B'
+	littleEndianMarker = 'L'
+)
+
+// TelemetryFromTags collects the common telemetry fields from a parsed tag map.
+func TelemetryFromTags(tags map[int]*KLVTag) Telemetry {
+	var t Telemetry
+	if ts, ok := tags[2].PrecisionTime(); ok {
+		t.Timestamp = uint64(ts.UnixMicro())
+	} else if value, ok := tagFloat(tags, 2); ok {
+		t.Timestamp = uint64(value)
+	}
+	fields := t.fields()
+	for i, tag := range telemetryTags {
+		*fields[i] = math.NaN()
+		if value, ok := tagFloat(tags, tag); ok {
+			*fields[i] = value
+		}
+	}
+	return t
+}
+
+// Time returns the Precision Time Stamp as a time.Time.
+func (t Telemetry) Time() time.Time {
+	return time.UnixMicro(int64(t.Timestamp)).UTC()
+}
+
+// fields returns pointers to the float fields of t in record order.
+func (t *Telemetry) fields() []*float64 {
+	return []*float64{
+		&t.PlatformHeading,
+		&t.PlatformPitch,
+		&t.PlatformRoll,
+		&t.SensorLatitude,
+		&t.SensorLongitude,
+		&t.SensorAltitude,
+		&t.FrameCenterLatitude,
+		&t.FrameCenterLongitude,
+		&t.FrameCenterElevation,
+	}
+}
+
+// MarshalBinary encodes t as a fixed-size big-endian record.
+func (t Telemetry) MarshalBinary() ([]byte, error) {
+	return t.MarshalBinaryOrder(binary.BigEndian)
+}
+
+// MarshalBinaryOrder encodes t as a fixed-size record in the given byte order.
+// The record starts with a marker identifying the byte order, followed by the
+// timestamp and the float fields in declaration order.
+func (t Telemetry) MarshalBinaryOrder(order binary.ByteOrder) ([]byte, error) {
+	data := make([]byte, telemetryRecordSize)
+	switch order {
+	case binary.BigEndian:
+		data[0] = bigEndianMarker
+	case binary.LittleEndian:
+		data[0] = littleEndianMarker
+	default:
+		return nil, errors.New("unsupported byte order")
+	}
+	order.PutUint64(data[1:9], t.Timestamp)
+	for i, field := range t.fields() {
+		offset := 9 + 8*i
+		order.PutUint64(data[offset:offset+8], math.Float64bits(*field))
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a record produced by MarshalBinary or MarshalBinaryOrder.
+func (t *Telemetry) UnmarshalBinary(data []byte) error {
+	if len(data) != telemetryRecordSize {
+		return errors.New("invalid telemetry record length")
+	}
+	var order binary.ByteOrder
+	switch data[0] {
+	case bigEndianMarker:
+		order = binary.BigEndian
+	case littleEndianMarker:
+		order = binary.LittleEndian
+	default:
+		return errors.New("invalid telemetry record byte-order marker")
+	}
+	t.Timestamp = order.Uint64(data[1:9])
+	for i, field := range t.fields() {
+		offset := 9 + 8*i
+		*field = math.Float64frombits(order.Uint64(data[offset : offset+8]))
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package klvparser
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// sampleTelemetry returns a Telemetry with every field set but the frame center elevation.
+func sampleTelemetry() Telemetry {
+	return Telemetry{
+		Timestamp:            1700000000123456,
+		PlatformHeading:      159.97,
+		PlatformPitch:        -0.43,
+		PlatformRoll:         3.41,
+		SensorLatitude:       60.17,
+		SensorLongitude:      128.43,
+		SensorAltitude:       14190.7,
+		FrameCenterLatitude:  -10.54,
+		FrameCenterLongitude: 29.16,
+		FrameCenterElevation: math.NaN(),
+	}
+}
+
+func TestTelemetryBinaryRoundTrip(t *testing.T) {
+	want := sampleTelemetry()
+	for _, tt := range []struct {
+		order  binary.ByteOrder
+		marker byte
+	}{{binary.BigEndian, 'B'}, {binary.LittleEndian, 'L'}} {
+		data, err := want.MarshalBinaryOrder(tt.order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if data[0] != tt.marker {
+			t.Errorf("%v: marker %q, want %q", tt.order, data[0], tt.marker)
+		}
+		var got Telemetry
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("%v: %v", tt.order, err)
+		}
+		if got.Timestamp != want.Timestamp {
+			t.Errorf("%v: timestamp %d, want %d", tt.order, got.Timestamp, want.Timestamp)
+		}
+		gotFields, wantFields := got.fields(), want.fields()
+		for i := range wantFields {
+			if math.Float64bits(*gotFields[i]) != math.Float64bits(*wantFields[i]) {
+				t.Errorf("%v: field of tag %d = %v, want %v", tt.order, telemetryTags[i], *gotFields[i], *wantFields[i])
+			}
+		}
+	}
+
+	data, _ := want.MarshalBinary()
+	if data[0] != 'B' {
+		t.Errorf("MarshalBinary marker %q, want 'B'", data[0])
+	}
+	data[0] = 'X'
+	if err := new(Telemetry).UnmarshalBinary(data); err == nil {
+		t.Error("UnmarshalBinary accepted an unknown byte-order marker")
+	}
+	if err := new(Telemetry).UnmarshalBinary(data[:10]); err == nil {
+		t.Error("UnmarshalBinary accepted a short record")
+	}
+}
+
+func TestTelemetryEncodeRoundTrip(t *testing.T) {
+	want := sampleTelemetry()
+	packet, err := want.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tags := decodeOne(t, packet)
+	got := TelemetryFromTags(tags)
+	if got.Timestamp != want.Timestamp {
+		t.Errorf("timestamp %d, want %d", got.Timestamp, want.Timestamp)
+	}
+	if tags[65].Value != float64(encodedLSVersion) {
+		t.Errorf("LS version = %v, want %d", tags[65].Value, encodedLSVersion)
+	}
+	gotFields, wantFields := got.fields(), want.fields()
+	for i, tag := range telemetryTags {
+		if math.IsNaN(*wantFields[i]) {
+			if _, ok := tags[tag]; ok {
+				t.Errorf("tag %d encoded for a NaN field", tag)
+			}
+			continue
+		}
+		// Values are rounded to the nearest step of the wire encoding.
+		if step := tagEncodings[tag].scale; math.Abs(*gotFields[i]-*wantFields[i]) > step/2+1e-9 {
+			t.Errorf("tag %d = %v, want %v within %v", tag, *gotFields[i], *wantFields[i], step/2)
+		}
+	}
+}
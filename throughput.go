@@ -0,0 +1,57 @@
+package klvparser
+
+import "time"
+
+// throughputWindow is the span of the moving window used by Throughput.
+const throughputWindow = 5 * time.Second
+
+// throughputSample records the bytes and packets processed by one ProcessChunk call.
+type throughputSample struct {
+	at      time.Time
+	bytes   int
+	packets int
+}
+
+// throughputMeter keeps the samples that fall within the moving window.
+type throughputMeter struct {
+	samples []throughputSample
+}
+
+// add records a sample and discards those that have left the window.
+func (m *throughputMeter) add(at time.Time, bytes, packets int) {
+	m.samples = append(m.samples, throughputSample{at: at, bytes: bytes, packets: packets})
+	m.prune(at)
+}
+
+// prune discards the samples older than the window as of now.
+func (m *throughputMeter) prune(now time.Time) {
+	cutoff := now.Add(-throughputWindow)
+	drop := 0
+	for drop < len(m.samples) && m.samples[drop].at.Before(cutoff) {
+		drop++
+	}
+	m.samples = append(m.samples[:0], m.samples[drop:]...)
+}
+
+// Throughput returns the rate of input bytes and decoded packets per second over
+// a moving window of the last few seconds.
+func (p *KLVParser) Throughput() (bps, pps float64) {
+	now := p.now()
+	p.throughput.prune(now)
+	samples := p.throughput.samples
+	if len(samples) < 2 {
+		return 0, 0
+	}
+	// The first sample only marks the start of the measured span; the data it
+	// carries arrived before that span began.
+	elapsed := now.Sub(samples[0].at).Seconds()
+	if elapsed <= 0 {
+		return 0, 0
+	}
+	bytes, packets := 0, 0
+	for _, sample := range samples[1:] {
+		bytes += sample.bytes
+		packets += sample.packets
+	}
+	return float64(bytes) / elapsed, float64(packets) / elapsed
+}
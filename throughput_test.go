@@ -0,0 +1,35 @@
+package klvparser
+
+import (
+	"testing"
+	"time"
+)
+
+func TestThroughputCountsDecodedPackets(t *testing.T) {
+	p := NewKLVParser(func(map[int]*KLVTag) {})
+	p.Logger = nil
+	p.VerifyChecksum = true
+	at := time.Unix(1000, 0)
+	p.now = func() time.Time { return at }
+
+	good := pkt(true, item(5, 1, 2))
+	bad := append([]byte(nil), good...)
+	bad[len(bad)-1]++
+	chunks := [][]byte{good, append(append([]byte(nil), good...), bad...), good}
+	for _, chunk := range chunks {
+		if err := p.ProcessChunk(chunk); err != nil {
+			t.Fatal(err)
+		}
+		at = at.Add(time.Second)
+	}
+	at = at.Add(-time.Second)
+	// The first chunk only opens the window: two seconds for two chunks, one of
+	// whose two packets fails its checksum.
+	bps, pps := p.Throughput()
+	if want := float64(3*len(good)) / 2; bps != want {
+		t.Errorf("bps = %v, want %v", bps, want)
+	}
+	if pps != 1 {
+		t.Errorf("pps = %v, want 1", pps)
+	}
+}
@@ -0,0 +1,84 @@
+package klvparser
+
+import "math"
+
+// AngleUnit selects the unit of decoded angles.
+type AngleUnit int
+
+const (
+	// AngleDegrees delivers angles in degrees (°), as MISB ST 0601 defines them.
+	AngleDegrees AngleUnit = iota
+	// AngleRadians delivers angles in radians (rad).
+	AngleRadians
+)
+
+// DistanceUnit selects the unit of decoded distances and altitudes.
+type DistanceUnit int
+
+const (
+	// DistanceMeters delivers distances in meters (m), as MISB ST 0601 defines them.
+	DistanceMeters DistanceUnit = iota
+	// DistanceFeet delivers distances in international feet (ft).
+	DistanceFeet
+)
+
+// Units holds the preferred unit of each dimension. The zero value keeps the
+// units of MISB ST 0601: degrees and meters.
+type Units struct {
+	Angle    AngleUnit
+	Distance DistanceUnit
+}
+
+// metersPerFoot is the length of the international foot.
+const metersPerFoot = 0.3048
+
+// unit returns the preferred unit for values in base, one of the units of the
+// tag table. Units of other dimensions are returned unchanged.
+func (u Units) unit(base string) string {
+	switch {
+	case base == "°" && u.Angle == AngleRadians:
+		return "rad"
+	case base == "m" && u.Distance == DistanceFeet:
+		return "ft"
+	}
+	return base
+}
+
+// baseUnit returns the tag table unit of a unit produced by Units.
+func baseUnit(unit string) string {
+	switch unit {
+	case "rad":
+		return "°"
+	case "ft":
+		return "m"
+	}
+	return unit
+}
+
+// toBaseUnit converts a value in unit back to the tag table unit, degrees or meters.
+func toBaseUnit(unit string, value float64) float64 {
+	switch unit {
+	case "rad":
+		return value * 180 / math.Pi
+	case "ft":
+		return value * metersPerFoot
+	}
+	return value
+}
+
+// fromBaseUnit converts a value in the tag table unit to unit, the inverse of toBaseUnit.
+func fromBaseUnit(unit string, value float64) float64 {
+	switch unit {
+	case "rad":
+		return value * math.Pi / 180
+	case "ft":
+		return value / metersPerFoot
+	}
+	return value
+}
+
+// applyUnits records the preferred unit of the parser's Units on a tag.
+// Values in the tag table unit are then converted with fromBaseUnit.
+func (p *KLVParser) applyUnits(meta *KLVTag) {
+	meta.Unit = p.Units.unit(baseUnit(meta.Unit))
+}
@@ -1,45 +1,133 @@
 package klvparser
 
 import (
-	"log"
+	"encoding/binary"
+	"fmt"
+	"math"
 )
 
 // tolerance is used for floating-point comparisons to account for minor precision errors.
 // This constant helps to avoid issues due to the inherent imprecision of floating-point arithmetic.
 const tolerance = 0.00001
 
-// Check if the value is within the bounds defined in tagMeta.
-func checkBounds(tag int, value float64) bool {
-	meta, ok := tagMeta[tag]
+// reportError passes an error to the OnError handler, or logs it to Logger when no handler is set.
+func (p *KLVParser) reportError(tag int, err error) {
+	if p.OnError != nil {
+		p.OnError(tag, err)
+		return
+	}
+	if p.Logger != nil {
+		p.Logger.Printf("Warning: %v\n", err)
+	}
+}
+
+// Check if the value is within the bounds defined in the parser's tag table.
+func (p *KLVParser) checkBounds(tag int, value float64) bool {
+	meta, ok := p.tagMeta[tag]
 	if !ok {
-		log.Printf("No metadata for tag %d\n", tag)
+		p.reportError(tag, fmt.Errorf("%w: %d", ErrUnknownTag, tag))
 		return false
 	}
-	if value < meta.MinValue-tolerance || value > meta.MaxValue+tolerance {
-		log.Printf("Warning: Value for tag %d (%s) is out of bounds: %f (allowed: %f - %f)\n",
-			tag, meta.Name, value, meta.MinValue, meta.MaxValue)
+	// The bounds are in the tag table unit; convert them to the unit of the value.
+	minValue, maxValue := fromBaseUnit(meta.Unit, meta.MinValue), fromBaseUnit(meta.Unit, meta.MaxValue)
+	if value < minValue-tolerance || value > maxValue+tolerance {
+		p.reportError(tag, fmt.Errorf("%w: tag %d (%s) value %f, allowed %f to %f",
+			ErrOutOfBounds, tag, meta.Name, value, minValue, maxValue))
 		return false
 	}
 	return true
 }
 
-// Process a tag's value by checking bounds and assigning it to the tag.
-func processValue(tag int, value []byte, extractor func([]byte) *float64) {
-	meta := tagMeta[tag]
+// Process a tag's value by converting it to the parser's Units, checking bounds
+// and assigning it to the tag.
+func (p *KLVParser) processValue(tag int, value []byte, extractor func([]byte) *float64) {
+	meta := p.tagMeta[tag]
 	if meta == nil {
-		log.Printf("Warning: Unknown tag or uninitialized metadata for tag: %d\n", tag)
+		p.reportError(tag, fmt.Errorf("%w: %d", ErrUnknownTag, tag))
 		return
 	}
+	meta.OutOfRange = false
+	meta.Status = StatusOK
+	p.applyUnits(meta)
 	extractedValue := extractor(value)
 	if extractedValue == nil {
-		log.Printf("Warning: Failed to extract value for tag %d (%s)\n", tag, meta.Name)
+		meta.Value = nil
+		if len(value) >= 2 && isErrorSentinel(value) {
+			// The encoder marked the value as out of range; there is no number to report.
+			meta.OutOfRange = true
+			meta.Status = StatusOutOfBounds
+			return
+		}
+		meta.Status = StatusTruncated
+		p.reportError(tag, fmt.Errorf("%w: failed to extract tag %d (%s) from %d bytes", ErrInvalidLength, tag, meta.Name, len(value)))
+		return
+	}
+	converted := fromBaseUnit(meta.Unit, *extractedValue)
+	if !p.checkBounds(tag, converted) {
+		meta.Value = nil
+		meta.Status = StatusOutOfBounds
 		return
 	}
-	if !checkBounds(tag, *extractedValue) {
-		log.Printf("Warning: Tag %d (%s) value %f does not comply with bounds.\n", tag, meta.Name, *extractedValue)
+	meta.Value = converted
+}
+
+// processIMAPB decodes an IMAPB value onto the range of the tag, from MinValue to
+// MaxValue. The reserved codes leave the value absent: the infinities with
+// StatusOutOfBounds and NaN, the null indicator, with StatusNull.
+func (p *KLVParser) processIMAPB(tag int, value []byte) {
+	meta := p.tagMeta[tag]
+	if meta == nil {
+		p.reportError(tag, fmt.Errorf("%w: %d", ErrUnknownTag, tag))
+		return
+	}
+	if special, ok := imapbSpecial(value); ok {
+		p.applyUnits(meta)
+		meta.Value = nil
+		meta.OutOfRange = false
+		meta.Status = StatusNull
+		if !math.IsNaN(special) {
+			meta.OutOfRange = true
+			meta.Status = StatusOutOfBounds
+		}
 		return
 	}
-	meta.Value = *extractedValue
+	p.processValue(tag, value, func(val []byte) *float64 {
+		return extractIMAPBRange(val, meta.MinValue, meta.MaxValue)
+	})
+}
+
+// maxBERLengthSize is the largest long-form BER length field accepted, in bytes
+// following the initial byte. Four bytes cover any practical packet or value.
+const maxBERLengthSize = 4
+
+// decodeBERLength decodes the BER length field at the start of data: a single
+// byte below 0x80, or 0x80 plus the number of big-endian length bytes that
+// follow, up to maxBERLengthSize. It returns the length and the size of the
+// field, including the initial byte. ok is false when the field is malformed or
+// truncated; consumed is then the size the field claims when it runs past the
+// end of data, so streaming callers can wait for more, and 0 when it is malformed.
+func decodeBERLength(data []byte) (length int, consumed int, ok bool) {
+	if len(data) == 0 {
+		return 0, 1, false
+	}
+	if data[0]&0x80 == 0 {
+		return int(data[0]), 1, true
+	}
+	lengthSize := int(data[0] & 0x7F)
+	if lengthSize == 0 || lengthSize > maxBERLengthSize {
+		return 0, 0, false
+	}
+	if len(data) < 1+lengthSize {
+		return 0, 1 + lengthSize, false
+	}
+	for _, b := range data[1 : 1+lengthSize] {
+		length = (length << 8) | int(b)
+	}
+	if length < 0 {
+		// Four length bytes overflow int on 32-bit platforms.
+		return 0, 0, false
+	}
+	return length, 1 + lengthSize, true
 }
 
 // extractTagValue extracts the value of a tag from the byte array.
@@ -47,24 +135,12 @@ func (p *KLVParser) extractTagValue(valueBytes []byte, index int) (int, []byte,
 	if len(valueBytes) <= index {
 		return 0, nil, index
 	}
-	lengthByte := valueBytes[index]
-	index++
-
-	var length int
-	if lengthByte&0x80 == 0 {
-		length = int(lengthByte)
-	} else {
-		lengthSize := int(lengthByte & 0x7F)
-		if len(valueBytes) < index+lengthSize {
-			return 0, nil, index
-		}
-		length = 0
-		for i := 0; i < lengthSize; i++ {
-			length = (length << 8) | int(valueBytes[index+i])
-		}
-		index += lengthSize
+	length, consumed, ok := decodeBERLength(valueBytes[index:])
+	if !ok {
+		return 0, nil, index + 1
 	}
-	if len(valueBytes) < index+length {
+	index += consumed
+	if len(valueBytes)-index < length {
 		return 0, nil, index
 	}
 	tagValue := valueBytes[index : index+length]
@@ -72,14 +148,30 @@ func (p *KLVParser) extractTagValue(valueBytes []byte, index int) (int, []byte,
 	return length, tagValue, index
 }
 
-// extractKLVPacket extracts a KLV packet from the data buffer.
+// extractKLVPacket extracts a KLV packet from the data buffer. It returns a nil
+// packet while more data is needed, and ErrInvalidLength when the packet's length
+// field cannot be decoded.
 func (p *KLVParser) extractKLVPacket(data []byte) ([]byte, []byte, error) {
-	if len(data) < 17 {
+	if p.fixedValueLength > 0 {
+		totalPacketSize := universalKeyLength + p.fixedValueLength
+		if len(data) < totalPacketSize {
+			return nil, data, nil
+		}
+		return data[:totalPacketSize], data[totalPacketSize:], nil
+	}
+
+	if len(data) < universalKeyLength+1 {
 		return nil, data, nil
 	}
 
-	packetLength, lengthFieldSize := p.calculatePacketLength(data)
-	totalPacketSize := 16 + lengthFieldSize + int(packetLength)
+	packetLength, lengthFieldSize, err := p.calculatePacketLength(data)
+	if err != nil {
+		return nil, data, err
+	}
+	if lengthFieldSize == 0 {
+		return nil, data, nil
+	}
+	totalPacketSize := universalKeyLength + lengthFieldSize + int(packetLength)
 
 	if len(data) < totalPacketSize {
 		return nil, data, nil
@@ -88,32 +180,93 @@ func (p *KLVParser) extractKLVPacket(data []byte) ([]byte, []byte, error) {
 	return data[:totalPacketSize], data[totalPacketSize:], nil
 }
 
-// calculatePacketLength calculates the length of a KLV packet.
-func (p *KLVParser) calculatePacketLength(data []byte) (uint64, int) {
-	lengthByte := data[16]
-	if lengthByte&0x80 == 0 {
-		return uint64(lengthByte), 1
-	}
-	lengthFieldSize := int(lengthByte & 0x7F)
-	lengthBytes := data[17 : 17+lengthFieldSize]
-	packetLength := uint64(0)
-	for _, b := range lengthBytes {
-		packetLength = (packetLength << 8) | uint64(b)
+// calculatePacketLength calculates the length of a KLV packet's value and the size
+// of its length field, including the initial byte. The size is 0 when the length
+// field is not complete yet.
+func (p *KLVParser) calculatePacketLength(data []byte) (uint64, int, error) {
+	length, consumed, ok := decodeBERLength(data[universalKeyLength:])
+	if !ok {
+		if consumed == 0 {
+			return 0, 0, fmt.Errorf("%w: malformed packet length field 0x%02X", ErrInvalidLength, data[universalKeyLength])
+		}
+		return 0, 0, nil
 	}
-	return packetLength, lengthFieldSize
+	return uint64(length), consumed, nil
 }
 
 // getValueStartAndLength returns the start index and length of a KLV packet's value.
+// The length is -1 when the packet's length field is malformed or truncated.
 func (p *KLVParser) getValueStartAndLength(klvPacket []byte) (int, int) {
-	lengthByte := klvPacket[16]
-	if lengthByte&0x80 == 0 {
-		return 17, int(lengthByte)
+	if p.fixedValueLength > 0 {
+		return universalKeyLength, p.fixedValueLength
 	}
 
-	lengthSize := int(lengthByte & 0x7F)
-	length := 0
-	for i := 0; i < lengthSize; i++ {
-		length = (length << 8) | int(klvPacket[17+i])
+	length, consumed, ok := decodeBERLength(klvPacket[universalKeyLength:])
+	if !ok {
+		return universalKeyLength + 1, -1
+	}
+	return universalKeyLength + consumed, length
+}
+
+// calculateChecksum computes the MISB ST 0601 16-bit running sum over data.
+func calculateChecksum(data []byte) uint16 {
+	var sum uint16
+	for i, b := range data {
+		sum += uint16(b) << (8 * ((i + 1) % 2))
+	}
+	return sum
+}
+
+// packetChecksum locates tag 1 in a KLV packet and returns the checksum it carries
+// along with the checksum computed over the bytes preceding the checksum value.
+func (p *KLVParser) packetChecksum(klvPacket []byte, valueStart, length int) (uint16, uint16, bool) {
+	valueBytes := klvPacket[valueStart : valueStart+length]
+	index := 0
+	for index < len(valueBytes) {
+		tag := valueBytes[index]
+		index++
+		_, tagValue, newIndex := p.extractTagValue(valueBytes, index)
+		index = newIndex
+		if tag == 1 && len(tagValue) == 2 {
+			end := valueStart + index - len(tagValue)
+			return binary.BigEndian.Uint16(tagValue), calculateChecksum(klvPacket[:end]), true
+		}
+	}
+	return 0, 0, false
+}
+
+// checkChecksumPosition returns ErrChecksumPosition for a checksum (tag 1)
+// followed by further items. MISB ST 0601 requires the checksum to close the local set.
+func (p *KLVParser) checkChecksumPosition(valueBytes []byte) error {
+	items := p.splitLocalSet(valueBytes, localKeyLength)
+	for i, item := range items {
+		if item.key == 1 && i != len(items)-1 {
+			return fmt.Errorf("%w: followed by tag %d", ErrChecksumPosition, items[i+1].key)
+		}
+	}
+	return nil
+}
+
+// localSetItem is a single key/value pair of a local set.
+type localSetItem struct {
+	key   int
+	value []byte
+}
+
+// splitLocalSet splits a local set into its items, reading keys of keyLength bytes.
+// Top-level MISB ST 0601 items and nested local sets both use localKeyLength.
+func (p *KLVParser) splitLocalSet(data []byte, keyLength int) []localSetItem {
+	var items []localSetItem
+	index := 0
+	for index+keyLength <= len(data) {
+		key := 0
+		for i := 0; i < keyLength; i++ {
+			key = (key << 8) | int(data[index+i])
+		}
+		index += keyLength
+		_, value, newIndex := p.extractTagValue(data, index)
+		index = newIndex
+		items = append(items, localSetItem{key: key, value: value})
 	}
-	return 17 + lengthSize, length
+	return items
 }
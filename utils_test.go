@@ -0,0 +1,118 @@
+package klvparser
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestProcessIMAPBCourse(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  []byte
+		want   interface{}
+		status TagStatus
+	}{
+		{"bottom", []byte{2, 0x00, 0x00}, 0.0, StatusOK},
+		{"middle", []byte{2, 0x80, 0x01}, 360 * 32769 / 65535.0, StatusOK},
+		{"all ones", []byte{2, 0xFF, 0xFF}, 360.0, StatusOK},
+		{"null", []byte{2, 0xD0, 0x00}, nil, StatusNull},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag := decodeOne(t, pkt(true, item(112, tt.value...)))[112]
+			got, _ := tag.Value.(float64)
+			want, _ := tt.want.(float64)
+			match := (tag.Value == nil) == (tt.want == nil) && math.Abs(got-want) < 1e-9
+			if tag.Status != tt.status || !match {
+				t.Errorf("course = %v (%v), want %v (%v)", tag.Value, tag.Status, tt.want, tt.status)
+			}
+		})
+	}
+}
+
+func TestMisplacedChecksumDropsPacket(t *testing.T) {
+	decoded := 0
+	var errs []error
+	p := NewKLVParser(func(map[int]*KLVTag) { decoded++ })
+	p.VerifyChecksum = true
+	p.OnError = func(tag int, err error) { errs = append(errs, err) }
+	if err := p.ProcessChunk(pkt(false, item(1, 0, 0), item(5, 0x10, 0))); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != 0 {
+		t.Errorf("decoded a packet whose checksum is not the last tag")
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrChecksumPosition) {
+		t.Errorf("errors = %v, want one ErrChecksumPosition", errs)
+	}
+
+	errs = nil
+	if err := p.ProcessChunk(pkt(true, item(5, 0x10, 0))); err != nil {
+		t.Fatal(err)
+	}
+	if decoded != 1 || len(errs) != 0 {
+		t.Errorf("well-formed packet: decoded %d, errors %v", decoded, errs)
+	}
+}
+
+func TestDecodeBERLength(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []byte
+		length   int
+		consumed int
+		ok       bool
+	}{
+		{"short form", []byte{0x05}, 5, 1, true},
+		{"short form maximum", []byte{0x7F, 0x09}, 127, 1, true},
+		{"long form one byte", []byte{0x81, 0xC8}, 200, 2, true},
+		{"long form two bytes", []byte{0x82, 0x01, 0x00}, 256, 3, true},
+		{"long form four bytes", []byte{0x84, 0x7F, 0xFF, 0xFF, 0xFF}, 1<<31 - 1, 5, true},
+		{"long form incomplete", []byte{0x82, 0x01}, 0, 3, false},
+		{"empty", nil, 0, 1, false},
+		{"indefinite", []byte{0x80}, 0, 0, false},
+		{"over-long", []byte{0x85, 0, 0, 0, 0, 1}, 0, 0, false},
+	}
+	for _, tt := range tests {
+		length, consumed, ok := decodeBERLength(tt.data)
+		if length != tt.length || consumed != tt.consumed || ok != tt.ok {
+			t.Errorf("%s: decodeBERLength(% x) = %d, %d, %v; want %d, %d, %v",
+				tt.name, tt.data, length, consumed, ok, tt.length, tt.consumed, tt.ok)
+		}
+	}
+}
+
+func TestProcessIMAPBTargetWidth(t *testing.T) {
+	tests := []struct {
+		value []byte
+		want  float64
+	}{
+		{[]byte{3, 0x00, 0x00, 0x00}, 0},
+		{[]byte{3, 0x80, 0x00, 0x00}, 1500000 * 0x800000 / float64(0xFFFFFF)},
+		{[]byte{3, 0xFF, 0xFF, 0xFF}, 1500000},
+	}
+	for _, tt := range tests {
+		tag := decodeOne(t, pkt(true, item(96, tt.value...)))[96]
+		if got, _ := tag.Value.(float64); math.Abs(got-tt.want) > 1e-6 || tag.Unit != "m" {
+			t.Errorf("width of %X = %v %s, want %v m", tt.value[1:], tag.Value, tag.Unit, tt.want)
+		}
+	}
+}
+
+func TestProcessIMAPBRangeToRecovery(t *testing.T) {
+	tests := []struct {
+		value []byte
+		want  float64
+	}{
+		{[]byte{3, 0x00, 0x00, 0x00}, 0},
+		{[]byte{3, 0x80, 0x00, 0x00}, 21000 * 0x800000 / float64(0xFFFFFF)},
+		{[]byte{3, 0xFF, 0xFF, 0xFF}, 21000},
+	}
+	for _, tt := range tests {
+		tag := decodeOne(t, pkt(true, item(109, tt.value...)))[109]
+		if got, _ := tag.Value.(float64); math.Abs(got-tt.want) > 1e-6 || tag.Unit != "km" {
+			t.Errorf("range of %X = %v %s, want %v km", tt.value[1:], tag.Value, tag.Unit, tt.want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package klvparser
+
+import "fmt"
+
+// VMTI is a decoded MISB ST 0903 Video Moving Target Indicator local set (tag 74).
+type VMTI struct {
+	TotalTargets    int // Number of targets detected in the frame
+	ReportedTargets int // Number of targets the set announces in its VTarget Series; -1 when not carried
+	FrameNumber     int // Motion imagery frame number; 0 when not carried
+	FrameWidth      int // Frame width in pixels; 0 when not carried
+	FrameHeight     int // Frame height in pixels; 0 when not carried
+	Targets         []VMTITarget
+}
+
+// VMTITarget is an entry of the VTarget Series of a VMTI local set.
+type VMTITarget struct {
+	ID          int
+	Centroid    Pixel // Target centroid
+	TopLeft     Pixel // Top left corner of the bounding box
+	BottomRight Pixel // Bottom right corner of the bounding box
+	Priority    int   // Priority from 1 (highest) to 255; 0 when not carried
+	Confidence  int   // Detection confidence in percent; 0 when not carried
+}
+
+// Pixel locates a pixel of the frame. VMTI numbers pixels from 1, row by row.
+// Number is 0 when the pixel was not carried; Row and Column, counted from 1,
+// are 0 when the frame width is not known.
+type Pixel struct {
+	Number uint64
+	Row    int
+	Column int
+}
+
+// VMTI local set and VTarget pack items decoded by the parser.
+const (
+	vmtiTotalTargets    = 5
+	vmtiReportedTargets = 6
+	vmtiFrameNumber     = 7
+	vmtiFrameWidth      = 8
+	vmtiFrameHeight     = 9
+	vmtiTargetSeries    = 101
+
+	vtargetCentroid    = 1
+	vtargetTopLeft     = 2
+	vtargetBottomRight = 3
+	vtargetPriority    = 4
+	vtargetConfidence  = 5
+)
+
+// processVMTI stores a VMTI local set (tag 74) as a VMTI value. The set falls back
+// to a hex dump when a VTarget pack is malformed. A VTarget Series cut short
+// within a pack keeps the targets before it, sets StatusTruncated, and is reported
+// through OnError with ErrInvalidLength. A VTarget Series shorter or longer than
+// the announced number of reported targets is reported with ErrInconsistentTags.
+func (p *KLVParser) processVMTI(tag int, value []byte) {
+	meta := p.tagMeta[tag]
+	if meta == nil {
+		return
+	}
+	vmti, truncated := p.extractVMTI(value)
+	if vmti == nil {
+		meta.Value = extractHex(value)
+		return
+	}
+	meta.Value = *vmti
+	if truncated {
+		meta.Status = StatusTruncated
+		p.reportError(tag, fmt.Errorf("%w: VMTI VTarget Series is cut short after %d targets",
+			ErrInvalidLength, len(vmti.Targets)))
+		return
+	}
+	if vmti.ReportedTargets >= 0 && vmti.ReportedTargets != len(vmti.Targets) {
+		p.reportError(tag, fmt.Errorf("%w: VMTI set reports %d targets but carries %d",
+			ErrInconsistentTags, vmti.ReportedTargets, len(vmti.Targets)))
+	}
+}
+
+// extractVMTI decodes the frame items and the VTarget Series of a VMTI local set.
+// Other items are skipped. It returns nil when a target cannot be decoded, and
+// the targets decoded so far with truncated set when the series ends within a pack.
+func (p *KLVParser) extractVMTI(value []byte) (vmti *VMTI, truncated bool) {
+	vmti = &VMTI{ReportedTargets: -1}
+	var series []byte
+	for _, item := range p.splitLocalSet(value, localKeyLength) {
+		switch item.key {
+		case vmtiTotalTargets:
+			vmti.TotalTargets = int(extractUintN(item.value))
+		case vmtiReportedTargets:
+			vmti.ReportedTargets = int(extractUintN(item.value))
+		case vmtiFrameNumber:
+			vmti.FrameNumber = int(extractUintN(item.value))
+		case vmtiFrameWidth:
+			vmti.FrameWidth = int(extractUintN(item.value))
+		case vmtiFrameHeight:
+			vmti.FrameHeight = int(extractUintN(item.value))
+		case vmtiTargetSeries:
+			series = item.value
+		}
+	}
+	// Each entry of the series is a BER length followed by a VTarget pack.
+	for index := 0; index < len(series); {
+		_, pack, newIndex := p.extractTagValue(series, index)
+		if pack == nil {
+			return vmti, true
+		}
+		index = newIndex
+		target, ok := p.extractVTarget(pack, vmti.FrameWidth)
+		if !ok {
+			return nil, false
+		}
+		vmti.Targets = append(vmti.Targets, target)
+	}
+	return vmti, false
+}
+
+// extractVTarget decodes a VTarget pack: a BER-OID target ID followed by the
+// target's local set.
+func (p *KLVParser) extractVTarget(pack []byte, frameWidth int) (VMTITarget, bool) {
+	id, n := extractBEROID(pack)
+	if n == 0 {
+		return VMTITarget{}, false
+	}
+	target := VMTITarget{ID: int(id)}
+	for _, item := range p.splitLocalSet(pack[n:], localKeyLength) {
+		switch item.key {
+		case vtargetCentroid:
+			target.Centroid = newPixel(extractUintN(item.value), frameWidth)
+		case vtargetTopLeft:
+			target.TopLeft = newPixel(extractUintN(item.value), frameWidth)
+		case vtargetBottomRight:
+			target.BottomRight = newPixel(extractUintN(item.value), frameWidth)
+		case vtargetPriority:
+			target.Priority = int(extractUintN(item.value))
+		case vtargetConfidence:
+			target.Confidence = int(extractUintN(item.value))
+		}
+	}
+	return target, true
+}
+
+// newPixel locates a pixel number within a frame of the given width.
+func newPixel(number uint64, frameWidth int) Pixel {
+	pixel := Pixel{Number: number}
+	if number > 0 && frameWidth > 0 {
+		pixel.Row = int((number-1)/uint64(frameWidth)) + 1
+		pixel.Column = int((number-1)%uint64(frameWidth)) + 1
+	}
+	return pixel
+}
@@ -0,0 +1,70 @@
+package klvparser
+
+import (
+	"errors"
+	"testing"
+)
+
+// vtarget encodes a VTarget Series entry: the BER length, the target ID and items.
+func vtarget(id byte, items ...[]byte) []byte {
+	pack := []byte{id}
+	for _, it := range items {
+		pack = append(pack, it...)
+	}
+	return append(encodeBERLength(len(pack)), pack...)
+}
+
+func TestVMTI(t *testing.T) {
+	series := vtarget(3, item(1, 0, 0x01, 0x2D), item(2, 0x01), item(3, 0x02, 0x00), item(5, 80))
+	set := append(append(append(item(5, 2), item(7, 0, 9)...), item(8, 0, 100)...), item(101, series...)...)
+	tags := decodeOne(t, pkt(true, item(74, set...)))
+	vmti, ok := tags[74].Value.(VMTI)
+	if !ok {
+		t.Fatalf("tag 74 = %#v, want a VMTI", tags[74].Value)
+	}
+	if vmti.TotalTargets != 2 || vmti.FrameNumber != 9 || vmti.FrameWidth != 100 || len(vmti.Targets) != 1 {
+		t.Fatalf("VMTI = %+v", vmti)
+	}
+	target := vmti.Targets[0]
+	if target.ID != 3 || target.Centroid != (Pixel{Number: 301, Row: 4, Column: 1}) ||
+		target.BottomRight != (Pixel{Number: 512, Row: 6, Column: 12}) || target.Confidence != 80 {
+		t.Errorf("target = %+v", target)
+	}
+}
+
+func TestVMTIReportedTargetsMismatch(t *testing.T) {
+	var errs []error
+	p := NewKLVParser(func(map[int]*KLVTag) {})
+	p.OnError = func(tag int, err error) { errs = append(errs, err) }
+	series := append(vtarget(1), vtarget(2)...)
+	set := append(item(6, 3), item(101, series...)...)
+	if err := p.ProcessChunk(pkt(true, item(74, set...))); err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrInconsistentTags) {
+		t.Fatalf("errors = %v, want one ErrInconsistentTags", errs)
+	}
+}
+
+func TestVMTITruncatedTarget(t *testing.T) {
+	var errs []error
+	var got map[int]*KLVTag
+	p := NewKLVParser(func(tags map[int]*KLVTag) { got = tags })
+	p.OnError = func(tag int, err error) { errs = append(errs, err) }
+	// The second pack announces 5 bytes but the series ends after 2.
+	series := append(vtarget(1, item(5, 90)), 5, 2, 1)
+	set := append(item(6, 2), item(101, series...)...)
+	if err := p.ProcessChunk(pkt(true, item(74, set...))); err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 || !errors.Is(errs[0], ErrInvalidLength) {
+		t.Fatalf("errors = %v, want one ErrInvalidLength", errs)
+	}
+	vmti, ok := got[74].Value.(VMTI)
+	if !ok || len(vmti.Targets) != 1 || vmti.Targets[0].Confidence != 90 {
+		t.Fatalf("tag 74 = %#v, want the first target", got[74].Value)
+	}
+	if got[74].Status != StatusTruncated {
+		t.Errorf("status = %v, want StatusTruncated", got[74].Status)
+	}
+}
@@ -0,0 +1,79 @@
+package klvparser
+
+import "encoding/binary"
+
+// Waypoint is an entry of the Waypoint List (tag 141).
+type Waypoint struct {
+	ID    int // Waypoint identifier
+	Order int // Prosecution order; waypoints with a negative order are not part of the route
+
+	Manual bool // Info bit 1: the waypoint is flown manually rather than automatically
+	AdHoc  bool // Info bit 2: the waypoint was added in flight rather than pre-planned
+
+	HasLocation bool    // Whether the record carries the location below
+	Latitude    float64 // Degrees
+	Longitude   float64 // Degrees
+	Altitude    float64 // Height above the ellipsoid in meters
+}
+
+// Waypoint info flags.
+const (
+	waypointManual = 1 << 0
+	waypointAdHoc  = 1 << 1
+)
+
+// waypointLocationLength is the length of a waypoint location: a 4-byte latitude,
+// a 4-byte longitude and a 3-byte height, each IMAPB-encoded.
+const waypointLocationLength = 11
+
+// extractWaypointList decodes a Waypoint List (tag 141): a series of records,
+// each a BER length followed by the BER-OID waypoint ID, the 2-byte signed
+// prosecution order, the BER-OID info flags, and optionally the location.
+// It returns nil when the value does not match this layout.
+func (p *KLVParser) extractWaypointList(value []byte) []Waypoint {
+	waypoints := []Waypoint{}
+	for index := 0; index < len(value); {
+		_, record, newIndex := p.extractTagValue(value, index)
+		if record == nil {
+			return nil
+		}
+		index = newIndex
+		waypoint, ok := extractWaypoint(record)
+		if !ok {
+			return nil
+		}
+		waypoints = append(waypoints, waypoint)
+	}
+	return waypoints
+}
+
+// extractWaypoint decodes a single Waypoint List record.
+func extractWaypoint(record []byte) (Waypoint, bool) {
+	id, n := extractBEROID(record)
+	if n == 0 || len(record) < n+2 {
+		return Waypoint{}, false
+	}
+	waypoint := Waypoint{
+		ID:    int(id),
+		Order: int(int16(binary.BigEndian.Uint16(record[n:]))),
+	}
+	record = record[n+2:]
+	info, n := extractBEROID(record)
+	if n == 0 {
+		return Waypoint{}, false
+	}
+	waypoint.Manual = info&waypointManual != 0
+	waypoint.AdHoc = info&waypointAdHoc != 0
+	record = record[n:]
+	switch len(record) {
+	case 0:
+	case waypointLocationLength:
+		waypoint.HasLocation = true
+		waypoint.Latitude = -90 + imapbFraction(record[0:4])*180
+		waypoint.Longitude = -180 + imapbFraction(record[4:8])*360
+		waypoint.Altitude = -900 + imapbFraction(record[8:11])*9900
+	default:
+		return Waypoint{}, false
+	}
+	return waypoint, true
+}
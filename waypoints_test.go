@@ -0,0 +1,24 @@
+package klvparser
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWaypointList(t *testing.T) {
+	// ID 7, order 2, both info flags set and no location.
+	first := []byte{4, 7, 0, 2, 3}
+	// ID 8, order -1, no flags, near 0°, 0° and at -900 m.
+	second := []byte{15, 8, 0xFF, 0xFF, 0, 0x80, 0, 0, 0, 0x80, 0, 0, 0, 0, 0, 0}
+	waypoints, ok := decodeOne(t, pkt(true, item(141, append(first, second...)...)))[141].Value.([]Waypoint)
+	if !ok || len(waypoints) != 2 {
+		t.Fatalf("waypoints = %#v, want two", waypoints)
+	}
+	if w := waypoints[0]; w.ID != 7 || w.Order != 2 || !w.Manual || !w.AdHoc || w.HasLocation {
+		t.Errorf("first waypoint = %+v", w)
+	}
+	if w := waypoints[1]; w.ID != 8 || w.Order != -1 || w.Manual || w.AdHoc || !w.HasLocation ||
+		math.Abs(w.Latitude) > 1e-6 || math.Abs(w.Longitude) > 1e-6 || w.Altitude != -900 {
+		t.Errorf("second waypoint = %+v", w)
+	}
+}